@@ -2,15 +2,20 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"os/signal"
 	"syscall"
 
+	"bot-viethoang/internal/config"
 	"bot-viethoang/internal/di"
 )
 
 func main() {
-	application, err := di.InitializeApp()
+	regenerate := flag.Bool("regenerate", false, "ignore cached insights from earlier runs and generate new ones")
+	flag.Parse()
+
+	application, err := di.InitializeApp(config.Flags{Regenerate: *regenerate})
 	if err != nil {
 		log.Fatalf("failed to initialize application: %v", err)
 	}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"bot-viethoang/internal/adapter/gotest"
+	"bot-viethoang/internal/adapter/leetcode"
+	"bot-viethoang/internal/adapter/logging"
+	"bot-viethoang/internal/domain/model"
+)
+
+func main() {
+	file := flag.String("file", "", "path to the Go solution file (required)")
+	slug := flag.String("slug", "", "problem slug, defaults to today's daily challenge")
+	timeout := flag.Duration("timeout", 60*time.Second, "time limit for compiling and running the examples")
+	emit := flag.Bool("emit-test", false, "print the generated test file instead of running it")
+	flag.Parse()
+
+	if *file == "" && !*emit {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger := logging.New(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	client := leetcode.New(30*time.Second, logger)
+
+	var (
+		problem *model.Problem
+		err     error
+	)
+	if *slug != "" {
+		problem, err = client.GetProblem(ctx, *slug)
+	} else {
+		problem, err = client.GetDailyChallenge(ctx)
+	}
+	if err != nil {
+		log.Fatalf("failed to fetch problem: %v", err)
+	}
+
+	if *emit {
+		test, err := gotest.GenerateTest(problem, "solution")
+		if err != nil {
+			log.Fatalf("failed to generate test: %v", err)
+		}
+		fmt.Print(test)
+		return
+	}
+
+	source, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("failed to read solution: %v", err)
+	}
+
+	result, err := gotest.NewRunner("", *timeout).Verify(ctx, problem, string(source))
+	if err != nil {
+		log.Fatalf("failed to verify solution: %v", err)
+	}
+
+	fmt.Print(result.Output)
+	if !result.Passed {
+		fmt.Printf("\n❌ %s: solution failed %d example(s) check in %s\n", problem.Title, result.Examples, result.Duration.Round(time.Millisecond))
+		os.Exit(1)
+	}
+	fmt.Printf("\n✅ %s: all %d example(s) passed in %s\n", problem.Title, result.Examples, result.Duration.Round(time.Millisecond))
+}
@@ -10,8 +10,9 @@ import (
 
 // CompositeProvider merges multiple article providers together.
 type CompositeProvider struct {
-	logger    ports.Logger
-	providers []ports.ArticleProvider
+	logger           ports.Logger
+	providers        []ports.ArticleProvider
+	requiredLanguage string
 }
 
 // NewCompositeProvider constructs a provider that queries the given providers sequentially.
@@ -28,6 +29,13 @@ func NewCompositeProvider(logger ports.Logger, providers ...ports.ArticleProvide
 	}
 }
 
+// RequireLanguage makes every result set contain at least one article in the given language,
+// replacing the last pick when needed. An empty language disables the guarantee.
+func (c *CompositeProvider) RequireLanguage(language string) *CompositeProvider {
+	c.requiredLanguage = strings.ToLower(strings.TrimSpace(language))
+	return c
+}
+
 // GetRecommendedArticles returns up to count articles, de-duplicated by link/title.
 func (c *CompositeProvider) GetRecommendedArticles(ctx context.Context, count int) ([]model.Article, error) {
 	if count <= 0 {
@@ -37,13 +45,20 @@ func (c *CompositeProvider) GetRecommendedArticles(ctx context.Context, count in
 	results := make([]model.Article, 0, count)
 	seen := make(map[string]struct{})
 	var firstErr error
+	needLanguage := c.requiredLanguage != ""
 
 	for _, provider := range c.providers {
-		if len(results) >= count {
+		if len(results) >= count && !needLanguage {
 			break
 		}
 
-		items, err := provider.GetRecommendedArticles(ctx, count-len(results))
+		request := count - len(results)
+		if request <= 0 {
+			// Slots are full but the language guarantee is still pending.
+			request = 1
+		}
+
+		items, err := provider.GetRecommendedArticles(ctx, request)
 		if err != nil {
 			if firstErr == nil {
 				firstErr = err
@@ -64,14 +79,28 @@ func (c *CompositeProvider) GetRecommendedArticles(ctx context.Context, count in
 				continue
 			}
 			seen[key] = struct{}{}
-			results = append(results, item)
 
-			if len(results) >= count {
+			matchesLanguage := needLanguage && strings.EqualFold(item.Language, c.requiredLanguage)
+			if len(results) < count {
+				results = append(results, item)
+				if matchesLanguage {
+					needLanguage = false
+				}
+			} else if matchesLanguage {
+				results[len(results)-1] = item
+				needLanguage = false
+			}
+
+			if len(results) >= count && !needLanguage {
 				break
 			}
 		}
 	}
 
+	if needLanguage && len(results) > 0 && c.logger != nil {
+		c.logger.Info(ctx, "no article found in required language", "language", c.requiredLanguage)
+	}
+
 	if len(results) == 0 && firstErr != nil {
 		return nil, firstErr
 	}
@@ -0,0 +1,178 @@
+package articles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// ErrPaywalled is returned when an article body is hidden behind a paywall.
+var ErrPaywalled = errors.New("article is paywalled")
+
+var paywallMarkers = []string{
+	`"isAccessibleForFree":false`,
+	`"isAccessibleForFree":"False"`,
+	`"isAccessibleForFree": false`,
+	`"isLocked":true`,
+	"Member-only story",
+}
+
+var skippedElements = map[string]struct{}{
+	"script": {}, "style": {}, "noscript": {}, "nav": {}, "header": {},
+	"footer": {}, "aside": {}, "form": {}, "svg": {}, "iframe": {}, "button": {},
+}
+
+// ContentFetcher downloads article pages and extracts their main text.
+type ContentFetcher struct {
+	httpClient *http.Client
+	logger     ports.Logger
+	maxBytes   int64
+	maxChars   int
+}
+
+var _ ports.ArticleContentFetcher = (*ContentFetcher)(nil)
+
+// NewContentFetcher builds a fetcher that reads at most maxBytes of HTML and keeps at most maxChars of text.
+func NewContentFetcher(maxBytes int64, maxChars int, timeout time.Duration, logger ports.Logger) *ContentFetcher {
+	return &ContentFetcher{
+		httpClient: &http.Client{Timeout: timeout},
+		logger:     logger,
+		maxBytes:   maxBytes,
+		maxChars:   maxChars,
+	}
+}
+
+// FetchContent returns the readable text of the article, or ErrPaywalled for member-only pages.
+func (c *ContentFetcher) FetchContent(ctx context.Context, article model.Article) (string, error) {
+	if article.Link == "" {
+		return "", fmt.Errorf("article has no link")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, article.Link, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("create content request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; DailyBot/1.0; +https://github.com)")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch article: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPaymentRequired {
+		return "", ErrPaywalled
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("article status %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" && !strings.Contains(contentType, "html") {
+		return "", fmt.Errorf("unsupported content type %q", contentType)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBytes))
+	if err != nil {
+		return "", fmt.Errorf("read article body: %w", err)
+	}
+
+	page := string(data)
+	if isPaywalled(page) {
+		return "", ErrPaywalled
+	}
+
+	text := extractMainText(page)
+	if text == "" {
+		return "", fmt.Errorf("no readable text found")
+	}
+
+	return summarize(text, c.maxChars), nil
+}
+
+func isPaywalled(page string) bool {
+	for _, marker := range paywallMarkers {
+		if strings.Contains(page, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractMainText prefers <article>, then <main>, then <body>, and collects paragraph-level text.
+func extractMainText(page string) string {
+	root, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return ""
+	}
+
+	container := findElement(root, "article")
+	if container == nil {
+		container = findElement(root, "main")
+	}
+	if container == nil {
+		container = findElement(root, "body")
+	}
+	if container == nil {
+		return ""
+	}
+
+	var blocks []string
+	collectBlocks(container, &blocks)
+	return strings.Join(blocks, "\n")
+}
+
+func findElement(node *html.Node, tag string) *html.Node {
+	if node.Type == html.ElementNode && node.Data == tag {
+		return node
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func collectBlocks(node *html.Node, blocks *[]string) {
+	if node.Type == html.ElementNode {
+		if _, skip := skippedElements[node.Data]; skip {
+			return
+		}
+		switch node.Data {
+		case "p", "li", "h1", "h2", "h3", "h4", "pre", "blockquote":
+			var builder strings.Builder
+			collectText(node, &builder)
+			if text := strings.Join(strings.Fields(builder.String()), " "); text != "" {
+				*blocks = append(*blocks, text)
+			}
+			return
+		}
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		collectBlocks(child, blocks)
+	}
+}
+
+func collectText(node *html.Node, builder *strings.Builder) {
+	if node.Type == html.ElementNode {
+		if _, skip := skippedElements[node.Data]; skip {
+			return
+		}
+	}
+	if node.Type == html.TextNode {
+		builder.WriteString(node.Data)
+		builder.WriteByte(' ')
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		collectText(child, builder)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"strings"
 	"time"
 
 	"bot-viethoang/internal/domain/model"
@@ -52,9 +53,15 @@ func (d *DevToProvider) GetRecommendedArticles(ctx context.Context, count int) (
 	}
 
 	var payload []struct {
-		Title string `json:"title"`
-		URL   string `json:"url"`
-		User  struct {
+		Title         string   `json:"title"`
+		URL           string   `json:"url"`
+		Description   string   `json:"description"`
+		ReadingTime   int      `json:"reading_time_minutes"`
+		PublishedAt   string   `json:"published_at"`
+		TagList       []string `json:"tag_list"`
+		PositiveCount int      `json:"positive_reactions_count"`
+		CoverImage    *string  `json:"cover_image"`
+		User          struct {
 			Name     string `json:"name"`
 			Username string `json:"username"`
 		} `json:"user"`
@@ -86,10 +93,21 @@ func (d *DevToProvider) GetRecommendedArticles(ctx context.Context, count int) (
 		if source == "" {
 			source = "dev.to"
 		}
+		cover := ""
+		if item.CoverImage != nil {
+			cover = *item.CoverImage
+		}
 		articles = append(articles, model.Article{
-			Title:  item.Title,
-			Link:   item.URL,
-			Source: source,
+			Title:       item.Title,
+			Link:        item.URL,
+			Source:      source,
+			Author:      item.User.Username,
+			Summary:     strings.TrimSpace(item.Description),
+			PublishedAt: parseTimestamp(item.PublishedAt),
+			ReadingTime: item.ReadingTime,
+			Tags:        item.TagList,
+			Reactions:   item.PositiveCount,
+			CoverImage:  cover,
 		})
 	}
 
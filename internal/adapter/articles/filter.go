@@ -0,0 +1,189 @@
+package articles
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// overfetchFactor controls how many extra candidates are requested to survive filtering.
+const overfetchFactor = 3
+
+var spamTitlePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bmake money\b`),
+	regexp.MustCompile(`(?i)\bget rich\b`),
+	regexp.MustCompile(`(?i)\bpassive income\b`),
+	regexp.MustCompile(`(?i)\byou won'?t believe\b`),
+	regexp.MustCompile(`(?i)\b100% free\b`),
+	regexp.MustCompile(`(?i)\bbuy (now|cheap)\b`),
+	regexp.MustCompile(`(?i)\b(promo|coupon|discount) code\b`),
+	regexp.MustCompile(`[!?]{3,}`),
+}
+
+// FilterRules describes which candidates a FilteredProvider drops.
+type FilterRules struct {
+	BlockedKeywords []string
+	BlockedAuthors  []string
+	MinTitleLength  int
+	MaxTitleLength  int
+	// CheckPaywall fetches each candidate page to detect member-only content.
+	CheckPaywall bool
+}
+
+// FilteredProvider drops paywalled, spammy and low-quality articles from another provider.
+type FilteredProvider struct {
+	name     string
+	inner    ports.ArticleProvider
+	rules    FilterRules
+	pages    ports.ArticleContentFetcher
+	logger   ports.Logger
+	keywords []blockedKeyword
+	authors  map[string]struct{}
+}
+
+// blockedKeyword matches a keyword as a whole word, so "crypto" does not block "cryptography".
+type blockedKeyword struct {
+	keyword string
+	pattern *regexp.Regexp
+}
+
+var _ ports.ArticleProvider = (*FilteredProvider)(nil)
+
+// NewFilteredProvider wraps inner with the given rules. pages may be nil when CheckPaywall is off.
+func NewFilteredProvider(name string, inner ports.ArticleProvider, rules FilterRules, pages ports.ArticleContentFetcher, logger ports.Logger) *FilteredProvider {
+	keywords := make([]blockedKeyword, 0, len(rules.BlockedKeywords))
+	for _, keyword := range rules.BlockedKeywords {
+		if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+			// \b only knows ASCII word characters, so boundaries are spelled out for Vietnamese keywords.
+			pattern := regexp.MustCompile(`(?i)(^|[^\p{L}\p{N}_])` + regexp.QuoteMeta(keyword) + `($|[^\p{L}\p{N}_])`)
+			keywords = append(keywords, blockedKeyword{keyword: keyword, pattern: pattern})
+		}
+	}
+	authors := make(map[string]struct{}, len(rules.BlockedAuthors))
+	for _, author := range rules.BlockedAuthors {
+		if author = strings.ToLower(strings.TrimSpace(author)); author != "" {
+			authors[author] = struct{}{}
+		}
+	}
+	return &FilteredProvider{
+		name:     name,
+		inner:    inner,
+		rules:    rules,
+		pages:    pages,
+		logger:   logger,
+		keywords: keywords,
+		authors:  authors,
+	}
+}
+
+// GetRecommendedArticles over-fetches from the wrapped provider and returns up to count accepted articles.
+func (f *FilteredProvider) GetRecommendedArticles(ctx context.Context, count int) ([]model.Article, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+
+	candidates, err := f.inner.GetRecommendedArticles(ctx, count*overfetchFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted := make([]model.Article, 0, count)
+	seenTitles := make(map[string]struct{}, len(candidates))
+	for _, article := range candidates {
+		if len(accepted) >= count {
+			break
+		}
+
+		reason := f.rejectReason(article, seenTitles)
+		if reason == "" && f.rules.CheckPaywall && f.pages != nil && !article.MemberOnly {
+			content, err := f.pages.FetchContent(ctx, article)
+			switch {
+			case errors.Is(err, ErrPaywalled):
+				reason = "member-only page"
+			case err == nil:
+				article.Content = content
+			}
+		}
+
+		if reason != "" {
+			if f.logger != nil {
+				f.logger.Info(ctx, "article candidate dropped", "provider", f.name, "reason", reason, "title", article.Title, "link", article.Link)
+			}
+			continue
+		}
+		accepted = append(accepted, article)
+	}
+
+	return accepted, nil
+}
+
+func (f *FilteredProvider) rejectReason(article model.Article, seenTitles map[string]struct{}) string {
+	if article.MemberOnly {
+		return "member-only in feed"
+	}
+
+	title := strings.TrimSpace(article.Title)
+	if f.rules.MinTitleLength > 0 && len([]rune(title)) < f.rules.MinTitleLength {
+		return "title too short"
+	}
+	if f.rules.MaxTitleLength > 0 && len([]rune(title)) > f.rules.MaxTitleLength {
+		return "title too long"
+	}
+
+	if _, blocked := f.authors[strings.ToLower(strings.TrimSpace(article.Author))]; blocked {
+		return "blocked author " + article.Author
+	}
+
+	haystack := title + "\n" + article.Summary + "\n" + strings.Join(article.Tags, "\n")
+	for _, blocked := range f.keywords {
+		if blocked.pattern.MatchString(haystack) {
+			return "blocked keyword " + blocked.keyword
+		}
+	}
+
+	for _, pattern := range spamTitlePatterns {
+		if pattern.MatchString(title) {
+			return "spam title pattern"
+		}
+	}
+
+	if !isLatinScript(title) {
+		return "unsupported language"
+	}
+
+	key := titleFingerprint(title)
+	if _, duplicate := seenTitles[key]; duplicate {
+		return "duplicate title"
+	}
+	seenTitles[key] = struct{}{}
+
+	return ""
+}
+
+// isLatinScript reports whether most letters are Latin, which covers English and Vietnamese.
+func isLatinScript(text string) bool {
+	letters, latin := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+		}
+	}
+	return letters == 0 || latin*5 >= letters*4
+}
+
+// titleFingerprint collapses titles that only differ by case, punctuation or numbering.
+func titleFingerprint(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return strings.Join(fields, " ")
+}
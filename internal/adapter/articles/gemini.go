@@ -5,31 +5,40 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
 
+	"bot-viethoang/internal/adapter/gemini"
 	"bot-viethoang/internal/domain/model"
 	"bot-viethoang/internal/domain/ports"
 )
 
-const geminiEndpointTemplate = "https://generativelanguage.googleapis.com/v1/models/%s:generateContent?key=%s"
+const (
+	geminiEndpointTemplate = "https://generativelanguage.googleapis.com/v1/models/%s:generateContent"
+	// geminiOperation labels the provider's calls in the usage records.
+	geminiOperation = "article_suggestions"
+)
 
 // GeminiProvider uses Google Gemini to suggest algorithm reading topics.
 type GeminiProvider struct {
 	httpClient *http.Client
-	apiKey     string
+	keys       *gemini.KeyPool
 	model      string
+	usage      ports.UsageTracker
 	logger     ports.Logger
 	topicLimit int
 }
 
-// NewGeminiProvider builds a Gemini-backed article provider.
-func NewGeminiProvider(apiKey, model string, timeout time.Duration, topicLimit int, logger ports.Logger) *GeminiProvider {
+// NewGeminiProvider builds a Gemini-backed article provider drawing keys from a shared pool. Its
+// calls count against the same token budget as the writer's; usage may be nil.
+func NewGeminiProvider(keys *gemini.KeyPool, model string, timeout time.Duration, topicLimit int, usage ports.UsageTracker, logger ports.Logger) *GeminiProvider {
 	return &GeminiProvider{
 		httpClient: &http.Client{Timeout: timeout},
-		apiKey:     apiKey,
+		keys:       keys,
 		model:      model,
+		usage:      usage,
 		logger:     logger,
 		topicLimit: topicLimit,
 	}
@@ -41,7 +50,7 @@ func (g *GeminiProvider) GetRecommendedArticles(ctx context.Context, count int)
 		return nil, nil
 	}
 
-	if g.apiKey == "" || g.model == "" {
+	if g.keys.Len() == 0 || g.model == "" {
 		return nil, fmt.Errorf("gemini configuration missing")
 	}
 
@@ -70,22 +79,19 @@ func (g *GeminiProvider) GetRecommendedArticles(ctx context.Context, count int)
 		return nil, fmt.Errorf("marshal gemini request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(geminiEndpointTemplate, g.model, g.apiKey), bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("create gemini request: %w", err)
+	if g.usage != nil {
+		if err := g.usage.Allow(ctx); err != nil {
+			return nil, err
+		}
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := g.httpClient.Do(req)
+	start := time.Now()
+	resp, err := g.post(ctx, body)
 	if err != nil {
-		return nil, fmt.Errorf("call gemini: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("gemini returned status %d", resp.StatusCode)
-	}
-
 	var payload struct {
 		Candidates []struct {
 			Content struct {
@@ -94,11 +100,17 @@ func (g *GeminiProvider) GetRecommendedArticles(ctx context.Context, count int)
 				} `json:"parts"`
 			} `json:"content"`
 		} `json:"candidates"`
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+			TotalTokenCount      int `json:"totalTokenCount"`
+		} `json:"usageMetadata"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
 		return nil, fmt.Errorf("decode gemini response: %w", err)
 	}
+	g.recordUsage(ctx, payload.UsageMetadata.PromptTokenCount, payload.UsageMetadata.CandidatesTokenCount, payload.UsageMetadata.TotalTokenCount, time.Since(start))
 
 	raw := g.extractFirstText(payload.Candidates)
 	if raw == "" {
@@ -108,13 +120,71 @@ func (g *GeminiProvider) GetRecommendedArticles(ctx context.Context, count int)
 	return g.parseArticles(raw, requestCount)
 }
 
+// post sends the request, rotating to the next pooled key while the current one is out of quota.
+func (g *GeminiProvider) post(ctx context.Context, body []byte) (*http.Response, error) {
+	for {
+		key, err := g.keys.Acquire(g.model)
+		if err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(geminiEndpointTemplate, g.model), bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("create gemini request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-goog-api-key", key.Value)
+
+		resp, err := g.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("call gemini: %w", err)
+		}
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+		if !gemini.IsQuotaError(resp.StatusCode, data) {
+			return nil, fmt.Errorf("gemini returned status %d", resp.StatusCode)
+		}
+
+		rotated := g.keys.ReportQuota(key, gemini.RetryDelay(resp.Header, data))
+		if g.logger != nil {
+			g.logger.Error(ctx, "gemini key hit quota", "key", key.ID, "rotated", rotated, "keys", g.keys.Health())
+		}
+		if !rotated {
+			return nil, fmt.Errorf("gemini returned status %d and no other key is available", resp.StatusCode)
+		}
+	}
+}
+
+func (g *GeminiProvider) recordUsage(ctx context.Context, promptTokens, candidateTokens, totalTokens int, latency time.Duration) {
+	if g.usage == nil {
+		return
+	}
+	err := g.usage.Record(ctx, model.LLMUsage{
+		Backend:         "gemini",
+		Model:           g.model,
+		Operation:       geminiOperation,
+		PromptTokens:    promptTokens,
+		CandidateTokens: candidateTokens,
+		TotalTokens:     totalTokens,
+		Latency:         latency,
+	})
+	if err != nil && g.logger != nil {
+		g.logger.Error(ctx, "record llm usage failed", "error", err)
+	}
+}
+
 func (g *GeminiProvider) buildPrompt(count int) string {
 	return fmt.Sprintf(`You are an expert algorithms mentor curating daily study material.
 Provide a JSON array with exactly %d unique items.
-Each item must have keys "title", "link", and "source".
+Each item must have keys "title", "link", "source", and "summary".
 - "title": concise topic or article title (max 80 characters).
 - "link": valid URL to a high-quality free resource (official docs, reputable blogs, lectures).
 - "source": the site or author name.
+- "summary": one sentence describing what the reader will learn.
 Do not include any additional text outside the JSON array.`, count)
 }
 
@@ -144,9 +214,10 @@ func (g *GeminiProvider) parseArticles(raw string, limit int) ([]model.Article,
 	raw = strings.TrimSpace(raw)
 
 	var items []struct {
-		Title  string `json:"title"`
-		Link   string `json:"link"`
-		Source string `json:"source"`
+		Title   string `json:"title"`
+		Link    string `json:"link"`
+		Source  string `json:"source"`
+		Summary string `json:"summary"`
 	}
 
 	if err := json.Unmarshal([]byte(raw), &items); err != nil {
@@ -177,9 +248,10 @@ func (g *GeminiProvider) parseArticles(raw string, limit int) ([]model.Article,
 		}
 
 		results = append(results, model.Article{
-			Title:  title,
-			Link:   link,
-			Source: source,
+			Title:   title,
+			Link:    link,
+			Source:  source,
+			Summary: strings.TrimSpace(item.Summary),
 		})
 	}
 
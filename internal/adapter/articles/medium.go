@@ -126,8 +126,13 @@ func (m *MediumProvider) fetchPrimary(ctx context.Context) ([]model.Article, err
 	payload := struct {
 		Channel struct {
 			Items []struct {
-				Title string `xml:"title"`
-				Link  string `xml:"link"`
+				Title       string   `xml:"title"`
+				Link        string   `xml:"link"`
+				PubDate     string   `xml:"pubDate"`
+				Description string   `xml:"description"`
+				Encoded     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+				Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+				Categories  []string `xml:"category"`
 			} `xml:"item"`
 		} `xml:"channel"`
 	}{}
@@ -144,10 +149,21 @@ func (m *MediumProvider) fetchPrimary(ctx context.Context) ([]model.Article, err
 		if title == "" || link == "" {
 			continue
 		}
+		body := plainText(item.Encoded)
+		summary := plainText(item.Description)
+		if summary == "" {
+			summary = body
+		}
 		articles = append(articles, model.Article{
-			Title:  title,
-			Link:   link,
-			Source: "Medium",
+			Title:       title,
+			Link:        link,
+			Source:      "Medium",
+			Author:      strings.TrimSpace(item.Creator),
+			Summary:     summarize(summary, summaryLimit),
+			PublishedAt: parseTimestamp(item.PubDate),
+			ReadingTime: estimateReadingTime(body),
+			Tags:        item.Categories,
+			MemberOnly:  isPaywalled(item.Encoded),
 		})
 	}
 
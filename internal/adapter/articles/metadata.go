@@ -0,0 +1,77 @@
+package articles
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	wordsPerMinute = 200
+	summaryLimit   = 280
+)
+
+var timestampLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+}
+
+// parseTimestamp accepts the date formats used by JSON APIs and RSS feeds.
+func parseTimestamp(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// plainText strips HTML markup and collapses whitespace.
+func plainText(fragment string) string {
+	if strings.TrimSpace(fragment) == "" {
+		return ""
+	}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(fragment))
+	var builder strings.Builder
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return strings.Join(strings.Fields(builder.String()), " ")
+		case html.TextToken:
+			builder.Write(tokenizer.Text())
+			builder.WriteByte(' ')
+		}
+	}
+}
+
+// summarize shortens text to limit characters (runes) without cutting a word in half.
+func summarize(text string, limit int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	trimmed := string(runes[:limit])
+	if idx := strings.LastIndex(trimmed, " "); idx > 0 {
+		trimmed = trimmed[:idx]
+	}
+	return trimmed + "..."
+}
+
+// estimateReadingTime approximates minutes needed to read text.
+func estimateReadingTime(text string) int {
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return 0
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
@@ -0,0 +1,151 @@
+package articles
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+const vibloTagFeedTemplate = "https://viblo.asia/rss/tags/%s.rss"
+
+// VibloProvider retrieves Vietnamese algorithm articles from Viblo tag feeds.
+type VibloProvider struct {
+	httpClient *http.Client
+	logger     ports.Logger
+	tags       []string
+	rnd        *rand.Rand
+}
+
+// NewVibloProvider builds a Viblo RSS provider for the given tag slugs.
+func NewVibloProvider(tags []string, timeout time.Duration, logger ports.Logger) *VibloProvider {
+	return &VibloProvider{
+		httpClient: &http.Client{Timeout: timeout},
+		logger:     logger,
+		tags:       tags,
+		rnd:        rand.New(rand.NewSource(time.Now().UnixNano() + 13)),
+	}
+}
+
+// GetRecommendedArticles fetches and samples Viblo posts across the configured tags.
+func (v *VibloProvider) GetRecommendedArticles(ctx context.Context, count int) ([]model.Article, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+
+	aggregated := make([]model.Article, 0)
+	seen := make(map[string]struct{})
+	var lastErr error
+
+	for _, tag := range v.tags {
+		items, err := v.fetchTag(ctx, tag)
+		if err != nil {
+			lastErr = err
+			if v.logger != nil {
+				v.logger.Error(ctx, "viblo tag feed failed", "tag", tag, "error", err)
+			}
+			continue
+		}
+		for _, item := range items {
+			key := strings.ToLower(item.Link)
+			if _, exists := seen[key]; exists {
+				continue
+			}
+			seen[key] = struct{}{}
+			aggregated = append(aggregated, item)
+		}
+	}
+
+	if len(aggregated) == 0 {
+		if lastErr != nil {
+			return nil, lastErr
+		}
+		return nil, fmt.Errorf("no viblo articles available")
+	}
+
+	v.rnd.Shuffle(len(aggregated), func(i, j int) {
+		aggregated[i], aggregated[j] = aggregated[j], aggregated[i]
+	})
+
+	if count > len(aggregated) {
+		count = len(aggregated)
+	}
+
+	return aggregated[:count], nil
+}
+
+func (v *VibloProvider) fetchTag(ctx context.Context, tag string) ([]model.Article, error) {
+	endpoint := fmt.Sprintf(vibloTagFeedTemplate, url.PathEscape(tag))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create viblo request: %w", err)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/xml")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; DailyBot/1.0; +https://github.com)")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch viblo feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("viblo feed status %d: %s", resp.StatusCode, string(body))
+	}
+
+	payload := struct {
+		Channel struct {
+			Items []struct {
+				Title       string   `xml:"title"`
+				Link        string   `xml:"link"`
+				PubDate     string   `xml:"pubDate"`
+				Description string   `xml:"description"`
+				Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+				Categories  []string `xml:"category"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}{}
+
+	if err := xml.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode viblo feed: %w", err)
+	}
+
+	articles := make([]model.Article, 0, len(payload.Channel.Items))
+	for _, item := range payload.Channel.Items {
+		title := strings.TrimSpace(item.Title)
+		link := strings.TrimSpace(item.Link)
+		if title == "" || link == "" {
+			continue
+		}
+		source := "Viblo"
+		if creator := strings.TrimSpace(item.Creator); creator != "" {
+			source = creator + " · Viblo"
+		}
+		description := plainText(item.Description)
+		articles = append(articles, model.Article{
+			Title:       title,
+			Link:        link,
+			Source:      source,
+			Author:      strings.TrimSpace(item.Creator),
+			Summary:     summarize(description, summaryLimit),
+			PublishedAt: parseTimestamp(item.PubDate),
+			Tags:        item.Categories,
+			Language:    "vi",
+		})
+	}
+
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("viblo feed for tag %q empty", tag)
+	}
+
+	return articles, nil
+}
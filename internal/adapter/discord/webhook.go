@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"strings"
 	"time"
@@ -35,6 +36,10 @@ func (w *Webhook) Send(ctx context.Context, notification model.Notification) err
 		return fmt.Errorf("webhook URL is empty")
 	}
 
+	if notification.Poll != nil {
+		return w.post(ctx, pollPayload(notification))
+	}
+
 	payload := map[string]any{
 		"content": "",
 		"embeds": []map[string]any{
@@ -55,6 +60,10 @@ func (w *Webhook) Send(ctx context.Context, notification model.Notification) err
 		},
 	}
 
+	return w.post(ctx, payload)
+}
+
+func (w *Webhook) post(ctx context.Context, payload map[string]any) error {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("marshal payload: %w", err)
@@ -80,6 +89,42 @@ func (w *Webhook) Send(ctx context.Context, notification model.Notification) err
 	return nil
 }
 
+// pollPayload builds a message carrying a native poll. Discord does not attach polls to embeds,
+// so the title and description become the message content.
+func pollPayload(notification model.Notification) map[string]any {
+	poll := notification.Poll
+
+	answers := make([]map[string]any, 0, len(poll.Answers))
+	for i, answer := range poll.Answers {
+		media := map[string]any{"text": truncate(answer, 55)}
+		if i < len(pollEmojis) {
+			media["emoji"] = map[string]string{"name": pollEmojis[i]}
+		}
+		answers = append(answers, map[string]any{"poll_media": media})
+	}
+
+	// Discord counts poll duration in whole hours; round up so the poll never closes early.
+	hours := max(int(math.Ceil(poll.Duration.Hours())), 1)
+
+	content := "**" + notification.Title + "**"
+	if notification.Description != "" {
+		content += "\n" + notification.Description
+	}
+
+	return map[string]any{
+		"content": truncate(content, 2000),
+		"poll": map[string]any{
+			"question":          map[string]string{"text": truncate(poll.Question, 300)},
+			"answers":           answers,
+			"duration":          min(hours, 768),
+			"allow_multiselect": false,
+			"layout_type":       1,
+		},
+	}
+}
+
+var pollEmojis = []string{"🇦", "🇧", "🇨", "🇩", "🇪", "🇫", "🇬", "🇭", "🇮", "🇯"}
+
 func convertFields(fields []model.NotificationField) []map[string]any {
 	if len(fields) == 0 {
 		return nil
@@ -97,9 +142,11 @@ func convertFields(fields []model.NotificationField) []map[string]any {
 	return result
 }
 
+// truncate caps value at limit characters, as Discord counts them, without splitting a rune.
 func truncate(value string, limit int) string {
-	if len(value) <= limit {
+	runes := []rune(value)
+	if len(runes) <= limit {
 		return value
 	}
-	return strings.TrimSpace(value[:limit-3]) + "..."
+	return strings.TrimSpace(string(runes[:limit-3])) + "..."
 }
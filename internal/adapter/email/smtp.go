@@ -0,0 +1,302 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"bot-viethoang/internal/adapter/markdown"
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// TLS modes for the SMTP connection.
+const (
+	// TLSStartTLS upgrades a plain connection, usually on port 587.
+	TLSStartTLS = "starttls"
+	// TLSImplicit connects over TLS from the start, usually on port 465.
+	TLSImplicit = "tls"
+	// TLSNone sends in clear text; meant for local SMTP sinks.
+	TLSNone = "none"
+)
+
+//go:embed templates/notification.html
+var notificationHTML string
+
+var htmlTemplate = template.Must(template.New("notification").Parse(notificationHTML))
+
+// Config holds the SMTP server, credentials and recipients.
+type Config struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+	TLS      string
+	Timeout  time.Duration
+}
+
+// SMTP is a notifier that emails each notification as multipart/alternative HTML and plain text.
+type SMTP struct {
+	cfg    Config
+	logger ports.Logger
+}
+
+// NewSMTP creates an email notifier. Authentication is skipped when no username is set.
+func NewSMTP(cfg Config, logger ports.Logger) *SMTP {
+	return &SMTP{cfg: cfg, logger: logger}
+}
+
+// Send renders the notification and delivers it to every recipient in one message.
+func (s *SMTP) Send(ctx context.Context, notification model.Notification) error {
+	if s.cfg.Host == "" || s.cfg.From == "" || len(s.cfg.To) == 0 {
+		return fmt.Errorf("smtp host, sender and recipients are required")
+	}
+
+	from, err := mail.ParseAddress(s.cfg.From)
+	if err != nil {
+		return fmt.Errorf("parse sender: %w", err)
+	}
+	to := make([]*mail.Address, 0, len(s.cfg.To))
+	recipients := make([]string, 0, len(s.cfg.To))
+	for _, raw := range s.cfg.To {
+		address, err := mail.ParseAddress(raw)
+		if err != nil {
+			return fmt.Errorf("parse recipient %q: %w", raw, err)
+		}
+		to = append(to, address)
+		recipients = append(recipients, address.Address)
+	}
+
+	message, err := buildMessage(from, to, notification, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := s.deliver(ctx, from.Address, recipients, message); err != nil {
+		return err
+	}
+
+	if s.logger != nil {
+		s.logger.Info(ctx, "notification sent by email", "recipients", len(recipients))
+	}
+	return nil
+}
+
+func (s *SMTP) deliver(ctx context.Context, from string, recipients []string, message []byte) error {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	tlsConfig := &tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}
+
+	dialer := &net.Dialer{Timeout: s.cfg.Timeout}
+	var conn net.Conn
+	var err error
+	if s.cfg.TLS == TLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("connect to smtp server: %w", err)
+	}
+	defer conn.Close()
+
+	// net/smtp has no context support; a deadline bounds the whole conversation instead.
+	var deadline time.Time
+	if s.cfg.Timeout > 0 {
+		deadline = time.Now().Add(s.cfg.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if !deadline.IsZero() {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return fmt.Errorf("set smtp deadline: %w", err)
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		return fmt.Errorf("open smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if s.cfg.TLS == TLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server does not support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("smtp MAIL FROM: %w", err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("smtp RCPT TO %s: %w", recipient, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("finish message: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMessage encodes the headers and a multipart/alternative body with the plain-text part first,
+// so clients that render HTML pick the last part.
+func buildMessage(from *mail.Address, to []*mail.Address, notification model.Notification, now time.Time) ([]byte, error) {
+	html, err := renderHTML(notification)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	alternatives := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", renderPlain(notification)},
+		{"text/html; charset=UTF-8", html},
+	}
+	for _, alternative := range alternatives {
+		part, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {alternative.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create mime part: %w", err)
+		}
+		encoder := quotedprintable.NewWriter(part)
+		if _, err := encoder.Write([]byte(alternative.content)); err != nil {
+			return nil, fmt.Errorf("encode mime part: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, fmt.Errorf("encode mime part: %w", err)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart body: %w", err)
+	}
+
+	var message bytes.Buffer
+	headers := []struct{ name, value string }{
+		{"From", from.String()},
+		{"To", joinAddresses(to)},
+		{"Subject", mime.QEncoding.Encode("utf-8", notification.Title)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", messageID(from.Address)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + parts.Boundary()},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", header.name, header.value)
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
+
+type htmlField struct {
+	Name  string
+	Value template.HTML
+}
+
+// renderHTML fills the email template. markdown.ToHTML escapes the text, so its output is trusted.
+func renderHTML(notification model.Notification) (string, error) {
+	fields := make([]htmlField, 0, len(notification.Fields))
+	for _, field := range notification.Fields {
+		fields = append(fields, htmlField{
+			Name:  markdown.ToPlain(field.Name),
+			Value: template.HTML(markdown.ToHTML(field.Value)),
+		})
+	}
+
+	data := struct {
+		Title       string
+		Description template.HTML
+		Poll        *model.Poll
+		Fields      []htmlField
+	}{
+		Title:       notification.Title,
+		Description: template.HTML(markdown.ToHTML(notification.Description)),
+		Poll:        notification.Poll,
+		Fields:      fields,
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render email template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func renderPlain(notification model.Notification) string {
+	var b strings.Builder
+	b.WriteString(notification.Title)
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("=", len([]rune(notification.Title))))
+	b.WriteString("\n")
+	if notification.Description != "" {
+		b.WriteString("\n" + markdown.ToPlain(notification.Description) + "\n")
+	}
+	if poll := notification.Poll; poll != nil {
+		b.WriteString("\n" + poll.Question + "\n")
+		for i, answer := range poll.Answers {
+			fmt.Fprintf(&b, "  %c. %s\n", 'A'+i, answer)
+		}
+	}
+	for _, field := range notification.Fields {
+		fmt.Fprintf(&b, "\n%s\n%s\n", markdown.ToPlain(field.Name), markdown.ToPlain(field.Value))
+	}
+	return b.String()
+}
+
+func joinAddresses(addresses []*mail.Address) string {
+	formatted := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		formatted = append(formatted, address.String())
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func messageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+	random := make([]byte, 12)
+	_, _ = rand.Read(random)
+	return "<" + hex.EncodeToString(random) + "@" + domain + ">"
+}
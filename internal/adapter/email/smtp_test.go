@@ -0,0 +1,178 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+// sinkMessage is what the SMTP sink received in one session.
+type sinkMessage struct {
+	from       string
+	recipients []string
+	data       string
+}
+
+// startSink runs a minimal SMTP server that accepts one message and hands it over on the channel.
+func startSink(t *testing.T) (host string, port int, received <-chan sinkMessage) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	messages := make(chan sinkMessage, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		text := textproto.NewConn(conn)
+
+		var msg sinkMessage
+		_ = text.PrintfLine("220 sink ready")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch verb {
+			case "EHLO", "HELO":
+				_ = text.PrintfLine("250 sink")
+			case "MAIL":
+				msg.from = line
+				_ = text.PrintfLine("250 ok")
+			case "RCPT":
+				msg.recipients = append(msg.recipients, line)
+				_ = text.PrintfLine("250 ok")
+			case "DATA":
+				_ = text.PrintfLine("354 go ahead")
+				data, err := io.ReadAll(text.DotReader())
+				if err != nil {
+					return
+				}
+				msg.data = string(data)
+				_ = text.PrintfLine("250 queued")
+				messages <- msg
+			case "QUIT":
+				_ = text.PrintfLine("221 bye")
+				return
+			default:
+				_ = text.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, messages
+}
+
+func TestSendDeliversMultipartAlternative(t *testing.T) {
+	host, port, received := startSink(t)
+	notifier := NewSMTP(Config{
+		Host:    host,
+		Port:    port,
+		From:    "Daily Bot <bot@example.com>",
+		To:      []string{"lead@example.com", "Quản lý <manager@example.com>"},
+		TLS:     TLSNone,
+		Timeout: 5 * time.Second,
+	}, nil)
+
+	notification := model.Notification{
+		Title:       "📅 Daily – Two Sum",
+		Description: "**[Two Sum](https://leetcode.com/problems/two-sum/)** – a < b & c",
+		Fields:      []model.NotificationField{{Name: "Approach", Value: "||hash map||"}},
+	}
+	if err := notifier.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	var msg sinkMessage
+	select {
+	case msg = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sink received nothing")
+	}
+	if msg.from != "MAIL FROM:<bot@example.com>" {
+		t.Errorf("MAIL = %q", msg.from)
+	}
+	if len(msg.recipients) != 2 || !strings.Contains(msg.recipients[1], "<manager@example.com>") {
+		t.Errorf("RCPT = %q", msg.recipients)
+	}
+
+	parsed, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(msg.data)))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != notification.Title {
+		t.Errorf("Subject = %q, %v", subject, err)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", mediaType, err)
+	}
+
+	parts := map[string]string{}
+	var order []string
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		// The reader decodes quoted-printable parts transparently.
+		body, _ := io.ReadAll(part)
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[contentType] = string(body)
+		order = append(order, contentType)
+	}
+	if strings.Join(order, ",") != "text/plain,text/html" {
+		t.Fatalf("parts = %v, want plain text before HTML", order)
+	}
+
+	plain := parts["text/plain"]
+	for _, want := range []string{"Two Sum (https://leetcode.com/problems/two-sum/) – a < b & c", "Approach"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("plain part misses %q:\n%s", want, plain)
+		}
+	}
+	if strings.Contains(plain, "hash map") || strings.Contains(plain, "**") {
+		t.Errorf("plain part leaks markup or a spoiler:\n%s", plain)
+	}
+
+	html := parts["text/html"]
+	for _, want := range []string{
+		`<b><a href="https://leetcode.com/problems/two-sum/">Two Sum</a></b> – a &lt; b &amp; c`,
+		`<span class="tg-spoiler">hash map</span>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML part misses %q:\n%s", want, html)
+		}
+	}
+}
+
+func TestSendRejectsInvalidRecipient(t *testing.T) {
+	notifier := NewSMTP(Config{Host: "127.0.0.1", Port: 1, From: "bot@example.com", To: []string{"not an address"}}, nil)
+	err := notifier.Send(context.Background(), model.Notification{Title: "t"})
+	if err == nil || !strings.Contains(err.Error(), strconv.Quote("not an address")) {
+		t.Errorf("err = %v, want the bad recipient", err)
+	}
+}
@@ -0,0 +1,197 @@
+package gemini
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Key is an API key handed out by a KeyPool for one model. ID identifies it in logs without
+// revealing it.
+type Key struct {
+	ID    string
+	Value string
+	index int
+	model string
+}
+
+// KeyHealth describes one key's state for logs and metrics. CoolingUntil lists the models the key
+// is cooling down for; Available is false while that list is not empty.
+type KeyHealth struct {
+	ID            string               `json:"id"`
+	Available     bool                 `json:"available"`
+	CoolingUntil  map[string]time.Time `json:"coolingUntil,omitempty"`
+	Requests      int                  `json:"requests"`
+	QuotaErrors   int                  `json:"quotaErrors"`
+	LastQuotaTime time.Time            `json:"lastQuotaTime"`
+}
+
+type keyState struct {
+	value       string
+	id          string
+	coolUntil   map[string]time.Time
+	requests    int
+	quotaErrors int
+	lastQuota   time.Time
+}
+
+func (s *keyState) coolingFor(model string, now time.Time) bool {
+	return now.Before(s.coolUntil[model])
+}
+
+// KeyPool shares Gemini API keys between callers. The current key is used until it hits a quota
+// error, then it cools down for that model and the pool rotates to the next key. Gemini counts
+// quota per model, so a key exhausted on one model stays usable for the others.
+type KeyPool struct {
+	mu       sync.Mutex
+	keys     []*keyState
+	current  int
+	cooldown time.Duration
+	now      func() time.Time
+}
+
+// NewKeyPool builds a pool from keys, skipping blanks and duplicates. cooldown applies when a
+// quota response carries no retry delay.
+func NewKeyPool(keys []string, cooldown time.Duration) *KeyPool {
+	pool := &KeyPool{cooldown: cooldown, now: time.Now}
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, dup := seen[key]; key == "" || dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		pool.keys = append(pool.keys, &keyState{value: key, id: fingerprint(len(pool.keys)+1, key), coolUntil: make(map[string]time.Time)})
+	}
+	return pool
+}
+
+// Len returns the number of keys in the pool.
+func (p *KeyPool) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.keys)
+}
+
+// Acquire returns the current key, or the next one that is not cooling down for model.
+func (p *KeyPool) Acquire(model string) (Key, error) {
+	if p.Len() == 0 {
+		return Key{}, fmt.Errorf("no gemini api keys configured")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	earliest := time.Time{}
+	for offset := 0; offset < len(p.keys); offset++ {
+		idx := (p.current + offset) % len(p.keys)
+		state := p.keys[idx]
+		if state.coolingFor(model, now) {
+			if until := state.coolUntil[model]; earliest.IsZero() || until.Before(earliest) {
+				earliest = until
+			}
+			continue
+		}
+		p.current = idx
+		state.requests++
+		return Key{ID: state.id, Value: state.value, index: idx, model: model}, nil
+	}
+	return Key{}, fmt.Errorf("all %d gemini api keys are cooling down for %s until %s", len(p.keys), model, earliest.Format(time.RFC3339))
+}
+
+// ReportQuota puts key on cooldown for the model it was acquired for, for retryAfter (or the pool
+// default), and rotates away from it. It reports whether another key is available for that model
+// right now.
+func (p *KeyPool) ReportQuota(key Key, retryAfter time.Duration) bool {
+	if p.Len() == 0 || key.index < 0 || key.index >= len(p.keys) {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if retryAfter < p.cooldown {
+		retryAfter = p.cooldown
+	}
+	now := p.now()
+	state := p.keys[key.index]
+	state.coolUntil[key.model] = now.Add(retryAfter)
+	state.quotaErrors++
+	state.lastQuota = now
+
+	for offset := 1; offset < len(p.keys); offset++ {
+		idx := (key.index + offset) % len(p.keys)
+		if !p.keys[idx].coolingFor(key.model, now) {
+			p.current = idx
+			return true
+		}
+	}
+	return false
+}
+
+// Health returns a snapshot of every key, identified only by its fingerprint.
+func (p *KeyPool) Health() []KeyHealth {
+	if p.Len() == 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	health := make([]KeyHealth, 0, len(p.keys))
+	for _, state := range p.keys {
+		entry := KeyHealth{
+			ID:            state.id,
+			Available:     true,
+			Requests:      state.requests,
+			QuotaErrors:   state.quotaErrors,
+			LastQuotaTime: state.lastQuota,
+		}
+		for model, until := range state.coolUntil {
+			if !now.Before(until) {
+				continue
+			}
+			if entry.CoolingUntil == nil {
+				entry.CoolingUntil = make(map[string]time.Time)
+			}
+			entry.CoolingUntil[model] = until
+			entry.Available = false
+		}
+		health = append(health, entry)
+	}
+	return health
+}
+
+var retryDelayPattern = regexp.MustCompile(`"retryDelay"\s*:\s*"(\d+(?:\.\d+)?)s"`)
+
+// IsQuotaError reports whether a response means the key ran out of quota.
+func IsQuotaError(status int, body []byte) bool {
+	return status == http.StatusTooManyRequests || bytes.Contains(body, []byte("RESOURCE_EXHAUSTED"))
+}
+
+// RetryDelay reads the wait suggested by a Retry-After header or Gemini's RetryInfo detail.
+func RetryDelay(header http.Header, body []byte) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if match := retryDelayPattern.FindSubmatch(body); match != nil {
+		if seconds, err := strconv.ParseFloat(string(match[1]), 64); err == nil {
+			return time.Duration(seconds * float64(time.Second))
+		}
+	}
+	return 0
+}
+
+// fingerprint labels a key by position and a short hash, which cannot be reversed into the key.
+func fingerprint(position int, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("key-%d:%s", position, hex.EncodeToString(sum[:3]))
+}
@@ -0,0 +1,34 @@
+package gemini
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeyPoolCoolsDownPerModel(t *testing.T) {
+	pool := NewKeyPool([]string{"only-key"}, time.Minute)
+
+	key, err := pool.Acquire("gemini-2.5-flash")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if pool.ReportQuota(key, 0) {
+		t.Fatal("ReportQuota rotated with a single key")
+	}
+
+	if _, err := pool.Acquire("gemini-2.5-flash"); err == nil {
+		t.Error("Acquire succeeded for the model on cooldown")
+	}
+	fallback, err := pool.Acquire("gemini-2.5-flash-lite")
+	if err != nil {
+		t.Fatalf("Acquire for another model: %v", err)
+	}
+	if fallback.Value != "only-key" {
+		t.Errorf("Acquire returned %q", fallback.Value)
+	}
+
+	health := pool.Health()
+	if len(health) != 1 || health[0].Available || len(health[0].CoolingUntil) != 1 {
+		t.Errorf("Health = %+v", health)
+	}
+}
@@ -0,0 +1,208 @@
+package gotest
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+// unsupportedTypes are LeetCode helper types the harness cannot build from JSON examples.
+var unsupportedTypes = []string{"ListNode", "TreeNode", "Node", "NestedInteger", "*"}
+
+// anyOrderPattern finds statements such as "You may return the answer in any order.", whose
+// examples show only one of the accepted orderings.
+var anyOrderPattern = regexp.MustCompile(`(?i)\bin any order\b`)
+
+type signature struct {
+	name   string
+	params []string
+	result string
+}
+
+// GenerateTest renders a _test.go file that checks the problem's examples against the Go function
+// declared in its LeetCode snippet.
+func GenerateTest(problem *model.Problem, packageName string) (string, error) {
+	if problem == nil {
+		return "", fmt.Errorf("problem is nil")
+	}
+	if problem.GoSnippet == "" {
+		return "", fmt.Errorf("problem %s has no Go snippet", problem.Slug)
+	}
+	if len(problem.Examples) == 0 {
+		return "", fmt.Errorf("problem %s has no parsed examples", problem.Slug)
+	}
+
+	sig, err := parseSignature(problem.GoSnippet)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated from the examples of %s. DO NOT EDIT.\n\n", problem.Slug)
+	fmt.Fprintf(&b, "package %s\n\n", packageName)
+	b.WriteString("import (\n\t\"encoding/json\"\n\t\"reflect\"\n\t\"sort\"\n\t\"testing\"\n)\n\n")
+	b.WriteString("// anyOrder is set when the statement accepts the elements of the answer in any order.\n")
+	fmt.Fprintf(&b, "const anyOrder = %t\n\n", anyOrderPattern.MatchString(problem.Content))
+	b.WriteString("func TestExamples(t *testing.T) {\n")
+	b.WriteString("\tcases := []struct {\n\t\targs []string\n\t\twant string\n\t}{\n")
+	for _, example := range problem.Examples {
+		if len(example.Input) != len(sig.params) {
+			return "", fmt.Errorf("example has %d arguments, %s takes %d", len(example.Input), sig.name, len(sig.params))
+		}
+		quoted := make([]string, 0, len(example.Input))
+		for _, arg := range example.Input {
+			quoted = append(quoted, strconv.Quote(arg))
+		}
+		fmt.Fprintf(&b, "\t\t{args: []string{%s}, want: %s},\n", strings.Join(quoted, ", "), strconv.Quote(example.Output))
+	}
+	b.WriteString("\t}\n\n")
+	b.WriteString("\tfor i, tc := range cases {\n")
+
+	args := make([]string, 0, len(sig.params))
+	for idx, paramType := range sig.params {
+		name := fmt.Sprintf("arg%d", idx)
+		args = append(args, name)
+		fmt.Fprintf(&b, "\t\tvar %s %s\n", name, paramType)
+		fmt.Fprintf(&b, "\t\tdecodeExample(t, i, tc.args[%d], &%s)\n", idx, name)
+	}
+
+	call := fmt.Sprintf("%s(%s)", sig.name, strings.Join(args, ", "))
+	if sig.result != "" {
+		fmt.Fprintf(&b, "\t\tgot := %s\n", call)
+	} else {
+		// In-place problems such as "rotate the array" are checked through their first argument.
+		fmt.Fprintf(&b, "\t\t%s\n\t\tgot := arg0\n", call)
+	}
+	b.WriteString("\t\tif !sameJSON(t, got, tc.want) {\n")
+	b.WriteString("\t\t\tt.Errorf(\"example %d: got %v, want %s\", i+1, got, tc.want)\n")
+	b.WriteString("\t\t}\n\t}\n}\n\n")
+	b.WriteString(helpers)
+
+	return b.String(), nil
+}
+
+func parseSignature(snippet string) (signature, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "snippet.go", "package snippet\n"+snippet, 0)
+	if err != nil {
+		return signature{}, fmt.Errorf("parse Go snippet: %w", err)
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+
+		sig := signature{name: fn.Name.Name}
+		for _, field := range fn.Type.Params.List {
+			typ := exprString(fset, field.Type)
+			if err := checkSupported(typ); err != nil {
+				return signature{}, err
+			}
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				sig.params = append(sig.params, typ)
+			}
+		}
+
+		if results := fn.Type.Results; results != nil {
+			if len(results.List) != 1 || len(results.List[0].Names) > 1 {
+				return signature{}, fmt.Errorf("%s returns multiple values", sig.name)
+			}
+			sig.result = exprString(fset, results.List[0].Type)
+			if err := checkSupported(sig.result); err != nil {
+				return signature{}, err
+			}
+		} else if len(sig.params) == 0 {
+			return signature{}, fmt.Errorf("%s takes and returns nothing", sig.name)
+		}
+
+		return sig, nil
+	}
+
+	return signature{}, fmt.Errorf("no top-level function in snippet (design problems are not supported)")
+}
+
+func checkSupported(typ string) error {
+	for _, unsupported := range unsupportedTypes {
+		if strings.Contains(typ, unsupported) {
+			return fmt.Errorf("parameter type %s is not supported by the example harness", typ)
+		}
+	}
+	return nil
+}
+
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, expr)
+	return buf.String()
+}
+
+const helpers = `func decodeExample(t *testing.T, example int, raw string, v any) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(raw), v); err != nil {
+		t.Fatalf("example %d: decode %q: %v", example+1, raw, err)
+	}
+}
+
+// sameJSON compares values through their JSON form so nil and empty slices and
+// float formatting such as 2.00000 versus 2 compare equal. When anyOrder is set the
+// top-level elements are compared as a multiset; nested lists keep their order.
+func sameJSON(t *testing.T, got any, want string) bool {
+	t.Helper()
+	encoded, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("encode result: %v", err)
+	}
+	var gotValue, wantValue any
+	if err := json.Unmarshal(encoded, &gotValue); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("decode expected %q: %v", want, err)
+	}
+	if gotValue == nil {
+		gotValue = []any{}
+	}
+	if wantValue == nil {
+		wantValue = []any{}
+	}
+	if anyOrder {
+		gotValue, wantValue = sortedElements(gotValue), sortedElements(wantValue)
+	}
+	return reflect.DeepEqual(gotValue, wantValue)
+}
+
+// sortedElements orders a decoded list by the JSON encoding of its elements.
+func sortedElements(value any) any {
+	list, ok := value.([]any)
+	if !ok {
+		return value
+	}
+	keys := make(map[int]string, len(list))
+	indexes := make([]int, len(list))
+	for i, element := range list {
+		encoded, _ := json.Marshal(element)
+		keys[i] = string(encoded)
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(a, b int) bool { return keys[indexes[a]] < keys[indexes[b]] })
+	sorted := make([]any, len(list))
+	for i, index := range indexes {
+		sorted[i] = list[index]
+	}
+	return sorted
+}
+`
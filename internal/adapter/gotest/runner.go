@@ -0,0 +1,123 @@
+package gotest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+const (
+	harnessPackage = "solution"
+	harnessModule  = "module solution\n\ngo 1.22\n"
+	maxOutputBytes = 16 * 1024
+)
+
+var packageClause = regexp.MustCompile(`(?m)^\s*package\s+\w+\s*$`)
+
+// Runner verifies Go solutions by running the generated example test in a throwaway module.
+type Runner struct {
+	goBinary string
+	timeout  time.Duration
+}
+
+var _ ports.SolutionVerifier = (*Runner)(nil)
+
+// NewRunner builds a Runner. goBinary defaults to "go" on PATH.
+func NewRunner(goBinary string, timeout time.Duration) *Runner {
+	if goBinary == "" {
+		goBinary = "go"
+	}
+	return &Runner{goBinary: goBinary, timeout: timeout}
+}
+
+// Verify writes source and the generated test into a temporary module and runs go test with a timeout.
+// A failing solution is reported through the result; err is reserved for harness problems.
+func (r *Runner) Verify(ctx context.Context, problem *model.Problem, source string) (model.VerificationResult, error) {
+	testFile, err := GenerateTest(problem, harnessPackage)
+	if err != nil {
+		return model.VerificationResult{}, err
+	}
+
+	dir, err := os.MkdirTemp("", "leetcode-solution-*")
+	if err != nil {
+		return model.VerificationResult{}, fmt.Errorf("create temp module: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"go.mod":           harnessModule,
+		"solution.go":      withPackage(source, harnessPackage),
+		"solution_test.go": testFile,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return model.VerificationResult{}, fmt.Errorf("write %s: %w", name, err)
+		}
+	}
+
+	runCtx := ctx
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	args := []string{"test", "-count=1"}
+	if r.timeout > 0 {
+		// go test's own timeout stops the test binary, which killing the go command alone would orphan.
+		args = append(args, "-timeout", r.timeout.String())
+	}
+	cmd := exec.CommandContext(runCtx, r.goBinary, append(args, "./...")...)
+	cmd.Dir = dir
+	cmd.WaitDelay = 5 * time.Second
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=", "GOPROXY=off")
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	start := time.Now()
+	runErr := cmd.Run()
+	result := model.VerificationResult{
+		Passed:   runErr == nil,
+		Examples: len(problem.Examples),
+		Output:   truncateOutput(output.String()),
+		Duration: time.Since(start),
+	}
+
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		result.Passed = false
+		result.Output += fmt.Sprintf("\ntimed out after %s", r.timeout)
+		return result, nil
+	}
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return result, fmt.Errorf("run go test: %w", runErr)
+	}
+	return result, nil
+}
+
+// withPackage sets the package clause, adding one to bare LeetCode submissions that have none.
+func withPackage(source, name string) string {
+	clause := "package " + name
+	if packageClause.MatchString(source) {
+		return packageClause.ReplaceAllString(source, clause)
+	}
+	return clause + "\n\n" + source
+}
+
+func truncateOutput(output string) string {
+	if len(output) <= maxOutputBytes {
+		return output
+	}
+	return output[:maxOutputBytes] + "\n... output truncated"
+}
@@ -0,0 +1,79 @@
+package gotest
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+func twoSumProblem(content string) *model.Problem {
+	return &model.Problem{
+		Slug:      "two-sum",
+		Content:   content,
+		GoSnippet: "func twoSum(nums []int, target int) []int {\n\n}",
+		Examples: []model.Example{
+			{Input: []string{"[2,7,11,15]", "9"}, Output: "[0,1]"},
+			{Input: []string{"[3,2,4]", "6"}, Output: "[1,2]"},
+		},
+	}
+}
+
+// reversedTwoSum returns the pair with the larger index first.
+const reversedTwoSum = `func twoSum(nums []int, target int) []int {
+	seen := map[int]int{}
+	for i, n := range nums {
+		if j, ok := seen[target-n]; ok {
+			return []int{i, j}
+		}
+		seen[n] = i
+	}
+	return nil
+}`
+
+func TestGenerateTestDetectsAnyOrder(t *testing.T) {
+	for content, want := range map[string]string{
+		"You can return the answer in any order.":    "const anyOrder = true",
+		"Return the indices in increasing order.":    "const anyOrder = false",
+		"You may return the answer IN ANY ORDER.":    "const anyOrder = true",
+		"Return them in any ordering you like best.": "const anyOrder = false",
+	} {
+		test, err := GenerateTest(twoSumProblem(content), "solution")
+		if err != nil {
+			t.Fatalf("GenerateTest: %v", err)
+		}
+		if !strings.Contains(test, want) {
+			t.Errorf("content %q: generated test lacks %q", content, want)
+		}
+	}
+}
+
+func TestVerifyAcceptsAnswerInAnyOrder(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not on PATH")
+	}
+	runner := NewRunner("", 2*time.Minute)
+
+	tests := []struct {
+		name    string
+		content string
+		passed  bool
+	}{
+		{name: "order accepted", content: "You can return the answer in any order.", passed: true},
+		{name: "order required", content: "Return the indices in increasing order.", passed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runner.Verify(context.Background(), twoSumProblem(tt.content), reversedTwoSum)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if result.Passed != tt.passed {
+				t.Errorf("Passed = %v, want %v\n%s", result.Passed, tt.passed, result.Output)
+			}
+		})
+	}
+}
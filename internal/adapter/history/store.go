@@ -0,0 +1,74 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"bot-viethoang/internal/adapter/filestore"
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+const runKeyPrefix = "run-"
+
+// Store keeps one JSON document per daily run.
+type Store struct {
+	files *filestore.Store
+}
+
+var _ ports.RunStore = (*Store)(nil)
+
+// New builds a run store on top of a file store.
+func New(files *filestore.Store) *Store {
+	return &Store{files: files}
+}
+
+// SaveRun replaces the run recorded for run.Date.
+func (s *Store) SaveRun(_ context.Context, run model.DailyRun) error {
+	if run.Date == "" {
+		return fmt.Errorf("run date is empty")
+	}
+	return s.files.Save(runKeyPrefix+run.Date, run)
+}
+
+// GetRun loads the run recorded for date, or nil when there is none.
+func (s *Store) GetRun(_ context.Context, date string) (*model.DailyRun, error) {
+	var run model.DailyRun
+	found, err := s.files.Load(runKeyPrefix+date, &run)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListRuns returns runs dated within [from, to], oldest first.
+func (s *Store) ListRuns(ctx context.Context, from, to string) ([]model.DailyRun, error) {
+	keys, err := s.files.Keys(runKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := make([]string, 0, len(keys))
+	for _, key := range keys {
+		date := strings.TrimPrefix(key, runKeyPrefix)
+		// Dates use a fixed-width layout, so string comparison orders them chronologically.
+		if date >= from && date <= to {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+
+	runs := make([]model.DailyRun, 0, len(dates))
+	for _, date := range dates {
+		run, err := s.GetRun(ctx, date)
+		if err != nil {
+			return nil, err
+		}
+		if run != nil {
+			runs = append(runs, *run)
+		}
+	}
+	return runs, nil
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"math/rand"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -39,76 +40,132 @@ func New(timeout time.Duration, logger ports.Logger) *Client {
 	}
 }
 
+const questionFields = `questionFrontendId title titleSlug difficulty content topicTags { name } exampleTestcaseList codeSnippets { langSlug code }`
+
+type graphQLQuestion struct {
+	QuestionFrontendID string `json:"questionFrontendId"`
+	Title              string `json:"title"`
+	TitleSlug          string `json:"titleSlug"`
+	Difficulty         string `json:"difficulty"`
+	Content            string `json:"content"`
+	TopicTags          []struct {
+		Name string `json:"name"`
+	} `json:"topicTags"`
+	ExampleTestcaseList []string `json:"exampleTestcaseList"`
+	CodeSnippets        []struct {
+		LangSlug string `json:"langSlug"`
+		Code     string `json:"code"`
+	} `json:"codeSnippets"`
+}
+
 // GetDailyChallenge retrieves the daily LeetCode challenge.
 func (c *Client) GetDailyChallenge(ctx context.Context) (*model.Problem, error) {
-	payload := map[string]string{
-		"query": `query questionOfToday { activeDailyCodingChallengeQuestion { link question { questionFrontendId title titleSlug difficulty content topicTags { name } } } }`,
+	payload := map[string]any{
+		"query": `query questionOfToday { activeDailyCodingChallengeQuestion { link question { ` + questionFields + ` } } }`,
+	}
+
+	var gqlResp struct {
+		Data struct {
+			ActiveDailyCodingChallengeQuestion struct {
+				Link     string          `json:"link"`
+				Question graphQLQuestion `json:"question"`
+			} `json:"activeDailyCodingChallengeQuestion"`
+		} `json:"data"`
+	}
+
+	if err := c.graphQL(ctx, payload, &gqlResp); err != nil {
+		return nil, err
+	}
+
+	q := gqlResp.Data.ActiveDailyCodingChallengeQuestion
+	if q.Question.TitleSlug == "" {
+		return nil, fmt.Errorf("empty daily challenge data")
+	}
+
+	return toProblem(q.Question, q.Link), nil
+}
+
+// GetProblem retrieves a single problem by its slug.
+func (c *Client) GetProblem(ctx context.Context, slug string) (*model.Problem, error) {
+	payload := map[string]any{
+		"query":     `query questionData($titleSlug: String!) { question(titleSlug: $titleSlug) { ` + questionFields + ` } }`,
+		"variables": map[string]string{"titleSlug": slug},
+	}
+
+	var gqlResp struct {
+		Data struct {
+			Question *graphQLQuestion `json:"question"`
+		} `json:"data"`
+	}
+
+	if err := c.graphQL(ctx, payload, &gqlResp); err != nil {
+		return nil, err
+	}
+
+	if gqlResp.Data.Question == nil || gqlResp.Data.Question.TitleSlug == "" {
+		return nil, fmt.Errorf("problem %q not found", slug)
 	}
 
+	return toProblem(*gqlResp.Data.Question, ""), nil
+}
+
+func (c *Client) graphQL(ctx context.Context, payload map[string]any, out any) error {
 	body, err := json.Marshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("marshal graphql payload: %w", err)
+		return fmt.Errorf("marshal graphql payload: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, graphQLEndpoint, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Referer", "https://leetcode.com")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("perform request: %w", err)
+		return fmt.Errorf("perform request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(data))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(data))
 	}
 
-	var gqlResp struct {
-		Data struct {
-			ActiveDailyCodingChallengeQuestion struct {
-				Link     string `json:"link"`
-				Question struct {
-					QuestionFrontendID string `json:"questionFrontendId"`
-					Title              string `json:"title"`
-					TitleSlug          string `json:"titleSlug"`
-					Difficulty         string `json:"difficulty"`
-					Content            string `json:"content"`
-					TopicTags          []struct {
-						Name string `json:"name"`
-					} `json:"topicTags"`
-				} `json:"question"`
-			} `json:"activeDailyCodingChallengeQuestion"`
-		} `json:"data"`
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
 	}
+	return nil
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&gqlResp); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+func toProblem(q graphQLQuestion, link string) *model.Problem {
+	topics := make([]string, 0, len(q.TopicTags))
+	for _, tag := range q.TopicTags {
+		topics = append(topics, tag.Name)
 	}
 
-	q := gqlResp.Data.ActiveDailyCodingChallengeQuestion
-	if q.Question.TitleSlug == "" {
-		return nil, fmt.Errorf("empty daily challenge data")
+	goSnippet := ""
+	for _, snippet := range q.CodeSnippets {
+		if snippet.LangSlug == "golang" {
+			goSnippet = snippet.Code
+			break
+		}
 	}
 
-	topics := make([]string, 0, len(q.Question.TopicTags))
-	for _, tag := range q.Question.TopicTags {
-		topics = append(topics, tag.Name)
-	}
+	content := strings.TrimSpace(htmlToText(q.Content))
 
 	return &model.Problem{
-		ID:         parseInt(q.Question.QuestionFrontendID),
-		Title:      q.Question.Title,
-		Slug:       q.Question.TitleSlug,
-		Difficulty: q.Question.Difficulty,
-		Link:       resolveLink(q.Question.TitleSlug, q.Link),
-		Content:    strings.TrimSpace(htmlToText(q.Question.Content)),
+		ID:         parseInt(q.QuestionFrontendID),
+		Title:      q.Title,
+		Slug:       q.TitleSlug,
+		Difficulty: q.Difficulty,
+		Link:       resolveLink(q.TitleSlug, link),
+		Content:    content,
 		Topics:     topics,
-	}, nil
+		GoSnippet:  goSnippet,
+		Examples:   parseExamples(q.ExampleTestcaseList, content),
+	}
 }
 
 // GetRandomProblems returns a random subset of problems from the global problemset.
@@ -183,6 +240,27 @@ func (c *Client) GetRandomProblems(ctx context.Context, count int) ([]model.Prob
 	return candidates[:count], nil
 }
 
+var exampleOutputPattern = regexp.MustCompile(`(?m)^\s*Output:\s*(.+?)\s*$`)
+
+// parseExamples pairs the example inputs (one argument per line) with the outputs quoted in the
+// statement. When the counts differ the pairing cannot be trusted, so no examples are returned.
+func parseExamples(inputs []string, content string) []model.Example {
+	outputs := exampleOutputPattern.FindAllStringSubmatch(content, -1)
+	if len(inputs) == 0 || len(inputs) != len(outputs) {
+		return nil
+	}
+
+	examples := make([]model.Example, 0, len(inputs))
+	for i := range inputs {
+		args := strings.Split(strings.TrimSpace(inputs[i]), "\n")
+		examples = append(examples, model.Example{
+			Input:  args,
+			Output: strings.TrimSpace(outputs[i][1]),
+		})
+	}
+	return examples
+}
+
 func difficultyToString(level int) string {
 	switch level {
 	case 1:
@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const redacted = "***"
+
+// minSecretLength keeps short or empty values from masking unrelated text.
+const minSecretLength = 6
+
+// secretPatterns mask credentials that are embedded in URLs even when the value itself is unknown.
+var secretPatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	// Discord and Slack webhook tokens.
+	{regexp.MustCompile(`(discord(?:app)?\.com/api/webhooks/\d+/)[\w-]+`), "${1}" + redacted},
+	{regexp.MustCompile(`(hooks\.slack\.com/services/)[\w/]+`), "${1}" + redacted},
+	// Telegram bot tokens inside API URLs.
+	{regexp.MustCompile(`(/bot)\d+:[\w-]+`), "${1}" + redacted},
+	// API keys and tokens passed as query parameters.
+	{regexp.MustCompile(`(?i)([?&](?:key|api_key|apikey|token|access_token)=)[^&\s"']+`), "${1}" + redacted},
+}
+
+// redactor masks known secret values and secret-bearing URL fragments.
+type redactor struct {
+	mu      sync.RWMutex
+	secrets []string
+}
+
+func newRedactor(secrets []string) *redactor {
+	r := &redactor{}
+	r.add(secrets)
+	return r
+}
+
+// add registers more secrets, such as tokens rotated while the bot runs.
+func (r *redactor) add(secrets []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, secret := range secrets {
+		if secret = strings.TrimSpace(secret); len(secret) >= minSecretLength && !slices.Contains(r.secrets, secret) {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+}
+
+func (r *redactor) redact(text string) string {
+	r.mu.RLock()
+	for _, secret := range r.secrets {
+		text = strings.ReplaceAll(text, secret, redacted)
+	}
+	r.mu.RUnlock()
+	for _, rule := range secretPatterns {
+		text = rule.pattern.ReplaceAllString(text, rule.replacement)
+	}
+	return text
+}
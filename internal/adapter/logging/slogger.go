@@ -7,16 +7,24 @@ import (
 	"bot-viethoang/internal/domain/ports"
 )
 
-// SLogger is an adapter around slog.Logger implementing ports.Logger.
+// SLogger is an adapter around slog.Logger implementing ports.Logger. Messages, string
+// attributes and error strings are redacted before they reach the handler.
 type SLogger struct {
-	logger *slog.Logger
+	logger   *slog.Logger
+	redactor *redactor
 }
 
 var _ ports.Logger = (*SLogger)(nil)
 
-// New creates a new SLogger.
-func New(logger *slog.Logger) *SLogger {
-	return &SLogger{logger: logger}
+// New creates a new SLogger that masks the given secrets, plus webhook and bot tokens and
+// key-like query parameters found in any logged URL.
+func New(logger *slog.Logger, secrets ...string) *SLogger {
+	return &SLogger{logger: logger, redactor: newRedactor(secrets)}
+}
+
+// AddSecrets masks values learned after start-up, such as refreshed access tokens.
+func (l *SLogger) AddSecrets(secrets ...string) {
+	l.redactor.add(secrets)
 }
 
 // Info logs an informational message.
@@ -24,7 +32,7 @@ func (l *SLogger) Info(ctx context.Context, msg string, args ...any) {
 	if l.logger == nil {
 		return
 	}
-	l.logger.Log(ctx, slog.LevelInfo, msg, args...)
+	l.logger.Log(ctx, slog.LevelInfo, l.redactor.redact(msg), l.redactArgs(args)...)
 }
 
 // Error logs an error message.
@@ -32,5 +40,36 @@ func (l *SLogger) Error(ctx context.Context, msg string, args ...any) {
 	if l.logger == nil {
 		return
 	}
-	l.logger.Log(ctx, slog.LevelError, msg, args...)
+	l.logger.Log(ctx, slog.LevelError, l.redactor.redact(msg), l.redactArgs(args)...)
+}
+
+func (l *SLogger) redactArgs(args []any) []any {
+	out := make([]any, len(args))
+	for i, arg := range args {
+		out[i] = l.redactValue(arg)
+	}
+	return out
+}
+
+func (l *SLogger) redactValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return l.redactor.redact(v)
+	case error:
+		return l.redactor.redact(v.Error())
+	case slog.Attr:
+		if v.Value.Kind() == slog.KindGroup {
+			group := v.Value.Group()
+			attrs := make([]slog.Attr, 0, len(group))
+			for _, attr := range group {
+				attrs = append(attrs, l.redactValue(attr).(slog.Attr))
+			}
+			return slog.Attr{Key: v.Key, Value: slog.GroupValue(attrs...)}
+		}
+		return slog.Any(v.Key, l.redactValue(v.Value.Any()))
+	case slog.Value:
+		return l.redactValue(v.Any())
+	default:
+		return value
+	}
 }
@@ -0,0 +1,75 @@
+// Package markdown converts the Discord-flavoured Markdown produced by the use cases for
+// notifiers that speak another markup.
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	codeBlockPattern  = regexp.MustCompile("(?s)```[a-zA-Z0-9_+-]*\n?(.*?)```")
+	inlineCodePattern = regexp.MustCompile("`([^`\n]+)`")
+	linkPattern       = regexp.MustCompile(`\[([^\]\n]+)\]\((https?://[^)\s]+)\)`)
+
+	headingPattern = regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
+	bulletPattern  = regexp.MustCompile(`(?m)^(\s*)[*-]\s+`)
+	quotePattern   = regexp.MustCompile(`(?m)^\s*&gt;\s?(.*)$`)
+	boldPattern    = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	italicPattern  = regexp.MustCompile(`(^|[^\w*])[*_]([^*_\n]+?)[*_]($|[^\w*])`)
+	strikePattern  = regexp.MustCompile(`~~(.+?)~~`)
+	spoilerPattern = regexp.MustCompile(`(?s)\|\|(.+?)\|\|`)
+)
+
+// tokenMarker wraps the index of a code span or link set aside during conversion.
+const tokenMarker = "\x01"
+
+var tokenPattern = regexp.MustCompile(tokenMarker + `(\d+)` + tokenMarker)
+
+// ToHTML renders Markdown as the small HTML subset Telegram accepts: b, i, s, a, code, pre and
+// blockquote. Spoilers use the tg-spoiler class, which other clients can style. Everything else
+// is escaped and line breaks are kept as newlines.
+func ToHTML(text string) string {
+	var tokens []string
+	setAside := func(value string) string {
+		tokens = append(tokens, value)
+		return tokenMarker + strconv.Itoa(len(tokens)-1) + tokenMarker
+	}
+
+	text = strings.ReplaceAll(text, tokenMarker, "")
+	text = codeBlockPattern.ReplaceAllStringFunc(text, func(block string) string {
+		code := codeBlockPattern.FindStringSubmatch(block)[1]
+		return setAside("<pre>" + html.EscapeString(strings.TrimRight(code, "\n")) + "</pre>")
+	})
+	text = inlineCodePattern.ReplaceAllStringFunc(text, func(code string) string {
+		return setAside("<code>" + html.EscapeString(inlineCodePattern.FindStringSubmatch(code)[1]) + "</code>")
+	})
+	text = linkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := linkPattern.FindStringSubmatch(link)
+		return setAside(`<a href="` + html.EscapeString(match[2]) + `">` + convertInline(match[1]) + "</a>")
+	})
+
+	text = headingPattern.ReplaceAllString(text, "**$1**")
+	text = bulletPattern.ReplaceAllString(text, "$1• ")
+	text = convertInline(text)
+	text = quotePattern.ReplaceAllString(text, "<blockquote>$1</blockquote>")
+
+	return tokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		index, _ := strconv.Atoi(strings.Trim(token, tokenMarker))
+		return tokens[index]
+	})
+}
+
+// convertInline escapes text and turns emphasis markers into tags.
+func convertInline(text string) string {
+	text = html.EscapeString(text)
+	text = boldPattern.ReplaceAllString(text, "<b>$1$2</b>")
+	// The pattern consumes the character after a match, so adjacent spans need a second pass.
+	for range 2 {
+		text = italicPattern.ReplaceAllString(text, "$1<i>$2</i>$3")
+	}
+	text = strikePattern.ReplaceAllString(text, "<s>$1</s>")
+	return spoilerPattern.ReplaceAllString(text, `<span class="tg-spoiler">$1</span>`)
+}
@@ -0,0 +1,107 @@
+package markdown
+
+import "testing"
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "angle brackets and ampersands are escaped",
+			input: "a < b && c > d",
+			want:  "a &lt; b &amp;&amp; c &gt; d",
+		},
+		{
+			name:  "bold link with query string",
+			input: "**[Two Sum](https://x.com/?a=1&b=2)** – Easy",
+			want:  `<b><a href="https://x.com/?a=1&amp;b=2">Two Sum</a></b> – Easy`,
+		},
+		{
+			name:  "nested bold and italic",
+			input: "**Hash *map* lookup**",
+			want:  "<b>Hash <i>map</i> lookup</b>",
+		},
+		{
+			name:  "bold inside a link label",
+			input: "[a **b**](https://x.com)",
+			want:  `<a href="https://x.com">a <b>b</b></a>`,
+		},
+		{
+			name:  "spoiler spanning lines",
+			input: "||two <pointers>\nfrom both ends||",
+			want:  "<span class=\"tg-spoiler\">two &lt;pointers&gt;\nfrom both ends</span>",
+		},
+		{
+			name:  "code is escaped but not formatted",
+			input: "`**a**<b>` and\n```go\nx := a && b\n```",
+			want:  "<code>**a**&lt;b&gt;</code> and\n<pre>x := a &amp;&amp; b</pre>",
+		},
+		{
+			name:  "heading, bullet and quote",
+			input: "## Plan\n- one\n> quote & more",
+			want:  "<b>Plan</b>\n• one\n<blockquote>quote &amp; more</blockquote>",
+		},
+		{
+			name:  "underscores inside words stay",
+			input: "snake_case_name and *it*",
+			want:  "snake_case_name and <i>it</i>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToHTML(tt.input); got != tt.want {
+				t.Errorf("ToHTML(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToPlain(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "special characters are kept as is",
+			input: "a < b && c > d",
+			want:  "a < b && c > d",
+		},
+		{
+			name:  "bold link keeps its URL",
+			input: "**[Two Sum](https://x.com/?a=1&b=2)** – Easy",
+			want:  "Two Sum (https://x.com/?a=1&b=2) – Easy",
+		},
+		{
+			name:  "nested emphasis",
+			input: "**Hash *map* lookup** ~~old~~",
+			want:  "Hash map lookup old",
+		},
+		{
+			name:  "spoilers are withheld",
+			input: "Approach: ||two pointers\nfrom both ends||",
+			want:  "Approach: " + SpoilerNotice,
+		},
+		{
+			name:  "code keeps its content",
+			input: "`a<b` then\n```go\nx := a && b\n```",
+			want:  "a<b then\nx := a && b",
+		},
+		{
+			name:  "heading",
+			input: "## Plan",
+			want:  "Plan",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToPlain(tt.input); got != tt.want {
+				t.Errorf("ToPlain(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
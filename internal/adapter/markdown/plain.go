@@ -0,0 +1,32 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SpoilerNotice stands in for spoiler text on clients that cannot hide it.
+const SpoilerNotice = "🙈 (ẩn để tránh spoil)"
+
+var plainReplacements = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{codeBlockPattern, "$1"},
+	{inlineCodePattern, "$1"},
+	{linkPattern, "$1 ($2)"},
+	{headingPattern, "$1"},
+	{boldPattern, "$1$2"},
+	{italicPattern, "$1$2$3"},
+	{strikePattern, "$1"},
+	{spoilerPattern, SpoilerNotice},
+}
+
+// ToPlain strips Markdown markers for plain-text clients; links keep their URL in parentheses and
+// spoilers are withheld.
+func ToPlain(text string) string {
+	for _, r := range plainReplacements {
+		text = r.pattern.ReplaceAllString(text, r.replacement)
+	}
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// Policy decides whether a fan-out counts as delivered.
+type Policy string
+
+const (
+	// PolicyAny succeeds when at least one destination received the notification.
+	PolicyAny Policy = "any"
+	// PolicyAll fails when any destination fails.
+	PolicyAll Policy = "all"
+)
+
+// Destination is a named notifier taking part in a fan-out. Profile selects the notification
+// edition written for it; empty means the default edition.
+type Destination struct {
+	Name     string
+	Notifier ports.Notifier
+	Profile  string
+}
+
+// Composite sends every notification to all destinations concurrently.
+type Composite struct {
+	logger       ports.Logger
+	policy       Policy
+	destinations []Destination
+	required     map[string]bool
+}
+
+// NewComposite constructs a fan-out notifier. Destinations without a notifier are skipped.
+func NewComposite(policy Policy, logger ports.Logger, destinations ...Destination) *Composite {
+	active := make([]Destination, 0, len(destinations))
+	for _, destination := range destinations {
+		if destination.Notifier != nil {
+			active = append(active, destination)
+		}
+	}
+	return &Composite{
+		logger:       logger,
+		policy:       policy,
+		destinations: active,
+		required:     make(map[string]bool),
+	}
+}
+
+// Require makes the fan-out fail whenever one of the named destinations fails, whatever the policy.
+func (c *Composite) Require(names ...string) *Composite {
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			c.required[name] = true
+		}
+	}
+	return c
+}
+
+// Send delivers the notification to every destination. One failing destination never stops the
+// others; the returned *ports.DeliveryError lists every outcome when the policy is not met.
+func (c *Composite) Send(ctx context.Context, notification model.Notification) error {
+	if len(c.destinations) == 0 {
+		return fmt.Errorf("no notification destinations configured")
+	}
+
+	deliveries := make([]ports.Delivery, len(c.destinations))
+	var wg sync.WaitGroup
+	for i, destination := range c.destinations {
+		wg.Add(1)
+		go func(i int, destination Destination) {
+			defer wg.Done()
+			start := time.Now()
+			err := destination.Notifier.Send(ctx, edition(notification, destination.Profile))
+			deliveries[i] = ports.Delivery{
+				Destination: destination.Name,
+				Err:         err,
+				Latency:     time.Since(start),
+			}
+		}(i, destination)
+	}
+	wg.Wait()
+
+	delivered := 0
+	requiredFailed := false
+	for _, delivery := range deliveries {
+		if delivery.Err == nil {
+			delivered++
+			if c.logger != nil {
+				c.logger.Info(ctx, "notification delivered", "destination", delivery.Destination, "latency", delivery.Latency)
+			}
+			continue
+		}
+		if c.required[delivery.Destination] {
+			requiredFailed = true
+		}
+		if c.logger != nil {
+			c.logger.Error(ctx, "notification delivery failed", "destination", delivery.Destination, "latency", delivery.Latency, "error", delivery.Err)
+		}
+	}
+
+	failed := len(deliveries) - delivered
+	switch {
+	case failed == 0:
+		return nil
+	case requiredFailed, delivered == 0, c.policy == PolicyAll:
+		return &ports.DeliveryError{Deliveries: deliveries}
+	default:
+		return nil
+	}
+}
+
+// edition returns the notification written for profile, or the default one without editions.
+func edition(notification model.Notification, profile string) model.Notification {
+	if written, ok := notification.Editions[profile]; ok && profile != "" {
+		return written
+	}
+	notification.Editions = nil
+	return notification
+}
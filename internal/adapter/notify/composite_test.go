@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+type stubNotifier struct {
+	err   error
+	calls atomic.Int32
+}
+
+func (s *stubNotifier) Send(context.Context, model.Notification) error {
+	s.calls.Add(1)
+	return s.err
+}
+
+func TestCompositeSend(t *testing.T) {
+	errDown := errors.New("down")
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		failing  []string
+		required []string
+		wantErr  bool
+	}{
+		{name: "any: all delivered", policy: PolicyAny},
+		{name: "any: one of three fails", policy: PolicyAny, failing: []string{"slack"}},
+		{name: "any: two of three fail", policy: PolicyAny, failing: []string{"slack", "email"}},
+		{name: "any: every destination fails", policy: PolicyAny, failing: []string{"discord", "slack", "email"}, wantErr: true},
+		{name: "all: all delivered", policy: PolicyAll},
+		{name: "all: one fails", policy: PolicyAll, failing: []string{"email"}, wantErr: true},
+		{name: "any: required destination fails", policy: PolicyAny, failing: []string{"discord"}, required: []string{"discord"}, wantErr: true},
+		{name: "any: optional destination fails beside a required one", policy: PolicyAny, failing: []string{"slack"}, required: []string{"discord"}},
+		{name: "all: required destinations delivered, another fails", policy: PolicyAll, failing: []string{"email"}, required: []string{"discord"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubs := map[string]*stubNotifier{}
+			var destinations []Destination
+			for _, name := range []string{"discord", "slack", "email"} {
+				stubs[name] = &stubNotifier{}
+				destinations = append(destinations, Destination{Name: name, Notifier: stubs[name]})
+			}
+			for _, name := range tt.failing {
+				stubs[name].err = errDown
+			}
+
+			err := NewComposite(tt.policy, nil, destinations...).Require(tt.required...).Send(context.Background(), model.Notification{Title: "t"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for name, stub := range stubs {
+				if stub.calls.Load() != 1 {
+					t.Errorf("%s called %d times, want 1", name, stub.calls.Load())
+				}
+			}
+			if err == nil {
+				return
+			}
+
+			var deliveryErr *ports.DeliveryError
+			if !errors.As(err, &deliveryErr) {
+				t.Fatalf("error %T is not a *ports.DeliveryError", err)
+			}
+			if len(deliveryErr.Deliveries) != len(destinations) {
+				t.Errorf("got %d deliveries, want one per destination", len(deliveryErr.Deliveries))
+			}
+			failed := 0
+			for _, delivery := range deliveryErr.Deliveries {
+				if delivery.Err != nil {
+					failed++
+				}
+			}
+			if failed != len(tt.failing) {
+				t.Errorf("%d deliveries failed, want %d", failed, len(tt.failing))
+			}
+			if !errors.Is(err, errDown) {
+				t.Error("per-destination errors are not unwrapped")
+			}
+		})
+	}
+}
+
+func TestCompositeWithoutDestinations(t *testing.T) {
+	composite := NewComposite(PolicyAny, nil, Destination{Name: "discord"})
+	if err := composite.Send(context.Background(), model.Notification{}); err == nil {
+		t.Error("expected an error when no destination has a notifier")
+	}
+}
+
+type capturingNotifier struct {
+	got model.Notification
+}
+
+func (c *capturingNotifier) Send(_ context.Context, notification model.Notification) error {
+	c.got = notification
+	return nil
+}
+
+func TestCompositeSendsProfileEdition(t *testing.T) {
+	formal, plain, unknown := &capturingNotifier{}, &capturingNotifier{}, &capturingNotifier{}
+	composite := NewComposite(PolicyAll, nil,
+		Destination{Name: "slack", Notifier: formal, Profile: "formal"},
+		Destination{Name: "discord", Notifier: plain},
+		Destination{Name: "email", Notifier: unknown, Profile: "casual"},
+	)
+
+	notification := model.Notification{
+		Title:    "default",
+		Editions: map[string]model.Notification{"formal": {Title: "formal"}},
+	}
+	if err := composite.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if formal.got.Title != "formal" {
+		t.Errorf("formal destination got %q, want the formal edition", formal.got.Title)
+	}
+	for name, stub := range map[string]*capturingNotifier{"discord": plain, "email": unknown} {
+		if stub.got.Title != "default" || stub.got.Editions != nil {
+			t.Errorf("%s got %q with editions %v, want the default edition alone", name, stub.got.Title, stub.got.Editions)
+		}
+	}
+}
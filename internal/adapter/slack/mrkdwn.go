@@ -0,0 +1,76 @@
+package slack
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"bot-viethoang/internal/adapter/markdown"
+)
+
+var (
+	// codePattern keeps code blocks and inline code out of the emphasis conversion.
+	codePattern = regexp.MustCompile("(?s)```.*?```|`[^`\n]+`")
+	linkPattern = regexp.MustCompile(`\[([^\]\n]+)\]\((https?://[^)\s]+)\)`)
+
+	headingPattern = regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
+	bulletPattern  = regexp.MustCompile(`(?m)^(\s*)[*-]\s+`)
+	quotePattern   = regexp.MustCompile(`(?m)^\s*&gt;\s?`)
+	boldPattern    = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	italicPattern  = regexp.MustCompile(`(^|[^\w*])\*([^*\n]+?)\*`)
+	strikePattern  = regexp.MustCompile(`~~(.+?)~~`)
+	spoilerPattern = regexp.MustCompile(`(?s)\|\|(.+?)\|\|`)
+)
+
+const (
+	// boldMarker stands in for converted bold so the italic pass does not see it.
+	boldMarker = "\x00"
+	// tokenMarker wraps the index of a code span or link set aside during conversion.
+	tokenMarker = "\x01"
+)
+
+var tokenPattern = regexp.MustCompile(tokenMarker + `(\d+)` + tokenMarker)
+
+// toMrkdwn converts the Discord-flavoured Markdown used by the use cases into Slack mrkdwn:
+// **bold** becomes *bold*, *italic* becomes _italic_, [text](url) becomes <url|text>, headings
+// turn bold and spoilers, which Slack cannot hide, are replaced by a notice. &, < and > are
+// escaped as Slack requires.
+func toMrkdwn(text string) string {
+	var tokens []string
+	setAside := func(value string) string {
+		tokens = append(tokens, value)
+		return tokenMarker + strconv.Itoa(len(tokens)-1) + tokenMarker
+	}
+
+	text = strings.NewReplacer(boldMarker, "", tokenMarker, "").Replace(text)
+	text = codePattern.ReplaceAllStringFunc(text, func(code string) string {
+		return setAside(escape(code))
+	})
+	text = linkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := linkPattern.FindStringSubmatch(link)
+		label := strings.NewReplacer("|", "¦", "**", "", "*", "", "__", "").Replace(match[1])
+		return setAside("<" + match[2] + "|" + escape(label) + ">")
+	})
+
+	text = convertEmphasis(text)
+	return tokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		index, _ := strconv.Atoi(strings.Trim(token, tokenMarker))
+		return tokens[index]
+	})
+}
+
+func convertEmphasis(text string) string {
+	text = escape(text)
+	text = quotePattern.ReplaceAllString(text, "> ")
+	text = headingPattern.ReplaceAllString(text, boldMarker+"$1"+boldMarker)
+	text = bulletPattern.ReplaceAllString(text, "$1• ")
+	text = boldPattern.ReplaceAllString(text, boldMarker+"$1$2"+boldMarker)
+	text = italicPattern.ReplaceAllString(text, "${1}_${2}_")
+	text = strikePattern.ReplaceAllString(text, "~$1~")
+	text = spoilerPattern.ReplaceAllString(text, markdown.SpoilerNotice)
+	return strings.ReplaceAll(text, boldMarker, "*")
+}
+
+func escape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
@@ -0,0 +1,70 @@
+package slack
+
+import "testing"
+
+func TestToMrkdwn(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "bold and italic",
+			input: "**Two Sum** is *easy*",
+			want:  "*Two Sum* is _easy_",
+		},
+		{
+			name:  "link",
+			input: "[Two Sum](https://leetcode.com/problems/two-sum/)",
+			want:  "<https://leetcode.com/problems/two-sum/|Two Sum>",
+		},
+		{
+			name:  "bold link label loses its markers",
+			input: "**[Two Sum](https://leetcode.com/problems/two-sum/)** – Easy",
+			want:  "*<https://leetcode.com/problems/two-sum/|Two Sum>* – Easy",
+		},
+		{
+			name:  "nested bold and italic",
+			input: "**Hash *map* lookup**",
+			want:  "*Hash _map_ lookup*",
+		},
+		{
+			name:  "angle brackets and ampersands are escaped",
+			input: "a < b && c > d",
+			want:  "a &lt; b &amp;&amp; c &gt; d",
+		},
+		{
+			name:  "pipe in link label cannot end the label",
+			input: "[a|b](https://example.com/?x=1&y=2)",
+			want:  "<https://example.com/?x=1&y=2|a¦b>",
+		},
+		{
+			name:  "code is escaped but not formatted",
+			input: "`**a** < b`",
+			want:  "`**a** &lt; b`",
+		},
+		{
+			name:  "heading, bullet and quote",
+			input: "## Plan\n- step <1>\n> note",
+			want:  "*Plan*\n• step &lt;1&gt;\n> note",
+		},
+		{
+			name:  "spoilers are withheld",
+			input: "Approach: ||two **pointers**\nfrom both ends||",
+			want:  "Approach: 🙈 (ẩn để tránh spoil)",
+		},
+		{
+			name:  "strikethrough",
+			input: "~~old~~ new",
+			want:  "~old~ new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toMrkdwn(tt.input); got != tt.want {
+				t.Errorf("toMrkdwn(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
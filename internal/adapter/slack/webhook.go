@@ -0,0 +1,204 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// Block Kit limits, counted in characters.
+const (
+	maxBlocks       = 50
+	maxHeaderText   = 150
+	maxSectionText  = 3000
+	maxSectionField = 2000
+	maxFieldsPerRow = 10
+	maxFallbackText = 3000
+)
+
+// Webhook is a Slack incoming-webhook notifier rendering notifications as Block Kit.
+type Webhook struct {
+	webhookURL string
+	httpClient *http.Client
+	logger     ports.Logger
+}
+
+// NewWebhook creates a new Slack webhook notifier.
+func NewWebhook(webhookURL string, timeout time.Duration, logger ports.Logger) *Webhook {
+	return &Webhook{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: timeout},
+		logger:     logger,
+	}
+}
+
+// Send posts the notification to Slack.
+func (w *Webhook) Send(ctx context.Context, notification model.Notification) error {
+	if w.webhookURL == "" {
+		return fmt.Errorf("slack webhook URL is empty")
+	}
+
+	payload := map[string]any{
+		"text":   fallbackText(notification),
+		"blocks": buildBlocks(notification),
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("perform request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Slack answers with a short reason such as invalid_blocks or no_text.
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	if w.logger != nil {
+		w.logger.Info(ctx, "notification sent to slack")
+	}
+	return nil
+}
+
+// buildBlocks renders a header, the description, one section per field and a footer. Consecutive
+// inline fields share a section; the poll, which Slack cannot post natively, is listed as text.
+func buildBlocks(notification model.Notification) []map[string]any {
+	var blocks []map[string]any
+	if notification.Title != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "header",
+			"text": plainText(truncate(notification.Title, maxHeaderText)),
+		})
+	}
+	if notification.Description != "" {
+		blocks = append(blocks, textSection(notification.Description))
+	}
+	if notification.Poll != nil {
+		blocks = append(blocks, textSection(pollText(notification.Poll)))
+	}
+
+	if len(notification.Fields) > 0 {
+		blocks = append(blocks, map[string]any{"type": "divider"})
+	}
+	var row []map[string]any
+	flush := func() {
+		if len(row) > 0 {
+			blocks = append(blocks, map[string]any{"type": "section", "fields": row})
+			row = nil
+		}
+	}
+	for _, field := range notification.Fields {
+		text := fieldText(field)
+		if !field.Inline {
+			flush()
+			blocks = append(blocks, textSection(text))
+			continue
+		}
+		row = append(row, mrkdwnText(truncateMrkdwn(toMrkdwn(text), maxSectionField)))
+		if len(row) == maxFieldsPerRow {
+			flush()
+		}
+	}
+	flush()
+
+	footer := map[string]any{
+		"type":     "context",
+		"elements": []map[string]any{mrkdwnText("🤖 Daily Bot")},
+	}
+	if len(blocks) >= maxBlocks {
+		blocks = blocks[:maxBlocks-1]
+		footer["elements"] = []map[string]any{mrkdwnText("🤖 Daily Bot • _nội dung đã được rút gọn_")}
+	}
+	return append(blocks, footer)
+}
+
+func textSection(markdown string) map[string]any {
+	return map[string]any{
+		"type": "section",
+		"text": mrkdwnText(truncateMrkdwn(toMrkdwn(markdown), maxSectionText)),
+	}
+}
+
+func fieldText(field model.NotificationField) string {
+	if field.Name == "" {
+		return field.Value
+	}
+	return "**" + field.Name + "**\n" + field.Value
+}
+
+func pollText(poll *model.Poll) string {
+	lines := []string{"**" + poll.Question + "**"}
+	for i, answer := range poll.Answers {
+		lines = append(lines, fmt.Sprintf("%c. %s", 'A'+i, answer))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fallbackText is shown in notifications and by clients that cannot render blocks.
+func fallbackText(notification model.Notification) string {
+	text := notification.Title
+	if notification.Description != "" {
+		text += "\n" + notification.Description
+	}
+	return truncateMrkdwn(toMrkdwn(text), maxFallbackText)
+}
+
+func plainText(text string) map[string]any {
+	return map[string]any{"type": "plain_text", "text": text, "emoji": true}
+}
+
+func mrkdwnText(text string) map[string]any {
+	return map[string]any{"type": "mrkdwn", "text": text}
+}
+
+// truncate caps value at limit characters without splitting a rune, preferring to cut at a line
+// break so links and emphasis in earlier lines stay intact.
+func truncate(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	cut := string(runes[:limit-1])
+	if idx := strings.LastIndex(cut, "\n"); idx > len(cut)/2 {
+		cut = cut[:idx]
+	}
+	return strings.TrimSpace(cut) + "…"
+}
+
+// partialEntity matches an escape sequence such as &amp; cut short at the end of the text.
+var partialEntity = regexp.MustCompile(`&[a-z]{0,3}$`)
+
+// truncateMrkdwn truncates converted mrkdwn and then trims back any <url|label> link or &amp;
+// entity the cut left open. Escaping means every < in the text opens a link.
+func truncateMrkdwn(text string, limit int) string {
+	cut := truncate(text, limit)
+	if cut == text {
+		return text
+	}
+	body := strings.TrimSuffix(cut, "…")
+	body = partialEntity.ReplaceAllString(body, "")
+	if open := strings.LastIndex(body, "<"); open > strings.LastIndex(body, ">") {
+		body = body[:open]
+	}
+	return strings.TrimSpace(body) + "…"
+}
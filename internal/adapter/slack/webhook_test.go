@@ -0,0 +1,137 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+type slackBlock struct {
+	Type string `json:"type"`
+	Text *struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"text"`
+	Fields []struct {
+		Text string `json:"text"`
+	} `json:"fields"`
+}
+
+type slackPayload struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// captureSlack starts a stub webhook and returns it with a pointer to the last payload received.
+func captureSlack(t *testing.T) (*httptest.Server, *slackPayload) {
+	t.Helper()
+	var payload slackPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &payload
+}
+
+func TestSendRendersBlockKit(t *testing.T) {
+	server, payload := captureSlack(t)
+	webhook := NewWebhook(server.URL, 5*time.Second, nil)
+
+	notification := model.Notification{
+		Title:       "📅 Daily – Two Sum",
+		Description: "**[Two Sum](https://leetcode.com/problems/two-sum/)** – a < b & c",
+		Fields: []model.NotificationField{
+			{Name: "Difficulty", Value: "Easy", Inline: true},
+			{Name: "Topics", Value: "Array", Inline: true},
+			{Name: "Articles", Value: "- [Go & you](https://go.dev/)"},
+		},
+		Poll: &model.Poll{Question: "Độ phức tạp?", Answers: []string{"O(1)", "O(n)"}},
+	}
+	if err := webhook.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if !strings.HasPrefix(payload.Text, "📅 Daily – Two Sum\n") {
+		t.Errorf("fallback text = %q", payload.Text)
+	}
+	types := make([]string, 0, len(payload.Blocks))
+	for _, block := range payload.Blocks {
+		types = append(types, block.Type)
+	}
+	if got := strings.Join(types, ","); got != "header,section,section,divider,section,section,context" {
+		t.Fatalf("block types = %s", got)
+	}
+
+	blocks := payload.Blocks
+	if blocks[0].Text.Type != "plain_text" || blocks[0].Text.Text != notification.Title {
+		t.Errorf("header = %+v", blocks[0].Text)
+	}
+	if got, want := blocks[1].Text.Text, "*<https://leetcode.com/problems/two-sum/|Two Sum>* – a &lt; b &amp; c"; got != want {
+		t.Errorf("description = %q, want %q", got, want)
+	}
+	if got := blocks[2].Text.Text; got != "*Độ phức tạp?*\nA. O(1)\nB. O(n)" {
+		t.Errorf("poll = %q", got)
+	}
+	if len(blocks[4].Fields) != 2 || blocks[4].Fields[0].Text != "*Difficulty*\nEasy" {
+		t.Errorf("inline fields = %+v", blocks[4].Fields)
+	}
+	if got := blocks[5].Text.Text; got != "*Articles*\n• <https://go.dev/|Go &amp; you>" {
+		t.Errorf("article field = %q", got)
+	}
+}
+
+func TestSendTruncatesWithoutBreakingLinks(t *testing.T) {
+	server, payload := captureSlack(t)
+	webhook := NewWebhook(server.URL, 5*time.Second, nil)
+
+	// One long line of links, so the cut cannot fall back to a line break.
+	var links []string
+	for range 200 {
+		links = append(links, "[Problem & more](https://leetcode.com/problems/a-rather-long-problem-slug/)")
+	}
+	description := strings.Join(links, " ")
+	if err := webhook.Send(context.Background(), model.Notification{Title: "Queue", Description: description}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	for name, text := range map[string]string{"section": payload.Blocks[1].Text.Text, "fallback": payload.Text} {
+		if n := utf8.RuneCountInString(text); n > maxSectionText {
+			t.Errorf("%s has %d characters, over the limit", name, n)
+		}
+		if !strings.HasSuffix(text, "…") {
+			t.Errorf("%s was not truncated", name)
+		}
+		body := strings.TrimSuffix(text, "…")
+		if strings.Count(body, "<") != strings.Count(body, ">") || !strings.HasSuffix(body, ">") {
+			t.Errorf("%s ends with a broken link: %q", name, body[max(len(body)-80, 0):])
+		}
+	}
+}
+
+func TestSendReportsSlackError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("invalid_blocks"))
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL, 5*time.Second, nil).Send(context.Background(), model.Notification{Title: "x"})
+	if err == nil || !strings.Contains(err.Error(), "invalid_blocks") {
+		t.Errorf("err = %v, want the Slack reason", err)
+	}
+}
@@ -0,0 +1,299 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"bot-viethoang/internal/adapter/markdown"
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+const (
+	// DefaultBaseURL is the public Bot API endpoint.
+	DefaultBaseURL = "https://api.telegram.org"
+
+	maxMessageLength = 4096
+	maxPollQuestion  = 300
+	maxPollOption    = 100
+	maxPollOptions   = 10
+	// maxRetryAfter caps how long a rate-limited request waits before its single retry.
+	maxRetryAfter = 30 * time.Second
+)
+
+// Bot posts notifications to one or more chats through the Telegram Bot API.
+type Bot struct {
+	baseURL    string
+	token      string
+	chatIDs    []string
+	httpClient *http.Client
+	logger     ports.Logger
+}
+
+// NewBot creates a Telegram notifier. An empty baseURL uses the public Bot API.
+func NewBot(baseURL, token string, chatIDs []string, timeout time.Duration, logger ports.Logger) *Bot {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Bot{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		chatIDs:    chatIDs,
+		httpClient: &http.Client{Timeout: timeout},
+		logger:     logger,
+	}
+}
+
+// Send posts the notification to every chat as HTML, split into messages Telegram accepts. A
+// failing chat does not stop the others; their errors are joined.
+func (b *Bot) Send(ctx context.Context, notification model.Notification) error {
+	if b.token == "" {
+		return fmt.Errorf("telegram bot token is empty")
+	}
+	if len(b.chatIDs) == 0 {
+		return fmt.Errorf("no telegram chat IDs configured")
+	}
+
+	messages := splitMessages(renderSections(notification), maxMessageLength)
+
+	var errs []error
+	for _, chatID := range b.chatIDs {
+		if err := b.sendChat(ctx, chatID, messages, notification.Poll); err != nil {
+			errs = append(errs, fmt.Errorf("chat %s: %w", chatID, err))
+			continue
+		}
+		if b.logger != nil {
+			b.logger.Info(ctx, "notification sent to telegram", "chat_id", chatID, "messages", len(messages))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *Bot) sendChat(ctx context.Context, chatID string, messages []string, poll *model.Poll) error {
+	for _, text := range messages {
+		payload := map[string]any{
+			"chat_id":              chatID,
+			"text":                 text,
+			"parse_mode":           "HTML",
+			"link_preview_options": map[string]bool{"is_disabled": true},
+		}
+		if err := b.call(ctx, "sendMessage", payload); err != nil {
+			return err
+		}
+	}
+	if poll == nil {
+		return nil
+	}
+	return b.call(ctx, "sendPoll", pollPayload(chatID, poll))
+}
+
+// pollPayload posts a native poll. Telegram closes polls after at most ten minutes, so the poll
+// stays open and the reveal job announces the answer.
+func pollPayload(chatID string, poll *model.Poll) map[string]any {
+	answers := poll.Answers
+	if len(answers) > maxPollOptions {
+		answers = answers[:maxPollOptions]
+	}
+	options := make([]map[string]string, 0, len(answers))
+	for _, answer := range answers {
+		options = append(options, map[string]string{"text": truncate(answer, maxPollOption)})
+	}
+	return map[string]any{
+		"chat_id":  chatID,
+		"question": truncate(poll.Question, maxPollQuestion),
+		"options":  options,
+	}
+}
+
+type apiResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// call invokes a Bot API method, retrying once when Telegram asks to slow down.
+func (b *Bot) call(ctx context.Context, method string, payload map[string]any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal %s payload: %w", method, err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		result, status, err := b.post(ctx, method, body)
+		if err != nil {
+			return err
+		}
+		if result.OK {
+			return nil
+		}
+
+		retryAfter := time.Duration(result.Parameters.RetryAfter) * time.Second
+		if status == http.StatusTooManyRequests && attempt == 0 && retryAfter <= maxRetryAfter {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(retryAfter):
+			}
+			continue
+		}
+		return fmt.Errorf("telegram %s returned status %d: %s", method, status, result.Description)
+	}
+}
+
+func (b *Bot) post(ctx context.Context, method string, body []byte) (apiResponse, int, error) {
+	endpoint := b.baseURL + "/bot" + b.token + "/" + method
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return apiResponse{}, 0, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		// The request URL carries the bot token; keep it out of the error.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return apiResponse{}, 0, fmt.Errorf("perform %s request: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var result apiResponse
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err := json.Unmarshal(data, &result); err != nil {
+		return apiResponse{}, resp.StatusCode, fmt.Errorf("telegram %s returned status %d", method, resp.StatusCode)
+	}
+	return result, resp.StatusCode, nil
+}
+
+// renderSections converts the title, description and each field into self-contained HTML so
+// messages can be split between sections without breaking tags.
+func renderSections(notification model.Notification) []string {
+	sections := []string{"<b>" + markdown.ToHTML(notification.Title) + "</b>"}
+	if notification.Description != "" {
+		sections = append(sections, splitSection(notification.Description, maxMessageLength)...)
+	}
+
+	for _, field := range notification.Fields {
+		value := field.Value
+		if field.Name != "" {
+			value = "**" + field.Name + "**\n" + value
+		}
+		sections = append(sections, splitSection(value, maxMessageLength)...)
+	}
+	return sections
+}
+
+// splitSection converts Markdown, breaking it at line boundaries when the HTML would not fit in
+// one message. A single oversized line is split as escaped plain text.
+func splitSection(value string, limit int) []string {
+	if rendered := markdown.ToHTML(value); textLength(rendered) <= limit {
+		return []string{rendered}
+	}
+
+	var parts []string
+	var chunk []string
+	flush := func() {
+		if len(chunk) > 0 {
+			parts = append(parts, markdown.ToHTML(strings.Join(chunk, "\n")))
+			chunk = nil
+		}
+	}
+	for _, line := range strings.Split(value, "\n") {
+		if textLength(markdown.ToHTML(line)) > limit {
+			flush()
+			parts = append(parts, splitPlain(line, limit)...)
+			continue
+		}
+		candidate := append(chunk, line)
+		if textLength(markdown.ToHTML(strings.Join(candidate, "\n"))) > limit {
+			flush()
+			candidate = []string{line}
+		}
+		chunk = candidate
+	}
+	flush()
+	return parts
+}
+
+// splitPlain escapes text and cuts it into pieces of at most limit code units, never inside an
+// entity.
+func splitPlain(text string, limit int) []string {
+	var parts []string
+	var piece strings.Builder
+	length := 0
+	for _, r := range text {
+		escaped := html.EscapeString(string(r))
+		size := textLength(escaped)
+		if length+size > limit {
+			parts = append(parts, piece.String())
+			piece.Reset()
+			length = 0
+		}
+		piece.WriteString(escaped)
+		length += size
+	}
+	if piece.Len() > 0 {
+		parts = append(parts, piece.String())
+	}
+	return parts
+}
+
+// splitMessages packs sections into as few messages as fit the limit.
+func splitMessages(sections []string, limit int) []string {
+	var messages []string
+	current := ""
+	for _, section := range sections {
+		if current == "" {
+			current = section
+			continue
+		}
+		if textLength(current)+2+textLength(section) > limit {
+			messages = append(messages, current)
+			current = section
+			continue
+		}
+		current += "\n\n" + section
+	}
+	if current != "" {
+		messages = append(messages, current)
+	}
+	return messages
+}
+
+// textLength counts UTF-16 code units, as Telegram does. Counting the raw HTML, tags included,
+// keeps every message safely under the limit.
+func textLength(text string) int {
+	return len(utf16.Encode([]rune(text)))
+}
+
+// truncate caps value at limit UTF-16 code units without splitting a rune.
+func truncate(value string, limit int) string {
+	if textLength(value) <= limit {
+		return value
+	}
+	var out strings.Builder
+	length := 0
+	for _, r := range value {
+		size := utf16.RuneLen(r)
+		if length+size > limit-1 {
+			break
+		}
+		out.WriteRune(r)
+		length += size
+	}
+	return out.String() + "…"
+}
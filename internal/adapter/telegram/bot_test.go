@@ -0,0 +1,149 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+type telegramCall struct {
+	Method  string
+	Payload map[string]any
+}
+
+// stubBotAPI records Bot API calls and fails the chats listed in failChats.
+type stubBotAPI struct {
+	mu        sync.Mutex
+	calls     []telegramCall
+	failChats map[string]bool
+}
+
+func (s *stubBotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := strings.TrimPrefix(r.URL.Path, "/botTOKEN/")
+	var payload map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+
+	s.mu.Lock()
+	s.calls = append(s.calls, telegramCall{Method: method, Payload: payload})
+	s.mu.Unlock()
+
+	if s.failChats[fmt.Sprint(payload["chat_id"])] {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok":false,"description":"Bad Request: chat not found"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"ok":true}`))
+}
+
+func newTestBot(t *testing.T, stub *stubBotAPI, chatIDs ...string) *Bot {
+	t.Helper()
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return NewBot(server.URL, "TOKEN", chatIDs, 5*time.Second, nil)
+}
+
+func TestSendPostsHTMLToEveryChat(t *testing.T) {
+	stub := &stubBotAPI{}
+	bot := newTestBot(t, stub, "100", "200")
+
+	notification := model.Notification{
+		Title:       "Daily <Two Sum>",
+		Description: "**[Two Sum](https://leetcode.com/problems/two-sum/)** – a < b & c",
+		Fields:      []model.NotificationField{{Name: "Approach", Value: "||hash map||"}},
+		Poll:        &model.Poll{Question: "Độ phức tạp?", Answers: []string{"O(1)", "O(n)"}},
+	}
+	if err := bot.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if len(stub.calls) != 4 {
+		t.Fatalf("got %d calls, want a message and a poll per chat", len(stub.calls))
+	}
+	message := stub.calls[0]
+	if message.Method != "sendMessage" || message.Payload["chat_id"] != "100" || message.Payload["parse_mode"] != "HTML" {
+		t.Errorf("first call = %+v", message)
+	}
+	want := "<b>Daily &lt;Two Sum&gt;</b>\n\n" +
+		`<b><a href="https://leetcode.com/problems/two-sum/">Two Sum</a></b> – a &lt; b &amp; c` + "\n\n" +
+		`<b>Approach</b>` + "\n" + `<span class="tg-spoiler">hash map</span>`
+	if got := message.Payload["text"]; got != want {
+		t.Errorf("text = %q\nwant %q", got, want)
+	}
+	if poll := stub.calls[1]; poll.Method != "sendPoll" || poll.Payload["question"] != "Độ phức tạp?" {
+		t.Errorf("second call = %+v", poll)
+	}
+	if stub.calls[2].Payload["chat_id"] != "200" {
+		t.Errorf("second chat not served: %+v", stub.calls[2])
+	}
+}
+
+func TestSendSplitsLongNotifications(t *testing.T) {
+	stub := &stubBotAPI{}
+	bot := newTestBot(t, stub, "100")
+
+	var fields []model.NotificationField
+	for i := range 12 {
+		fields = append(fields, model.NotificationField{
+			Name:  fmt.Sprintf("Section %d", i),
+			Value: strings.Repeat("- [Bài viết](https://example.com/a?b=1&c=2) về **đồ thị** & cây\n", 10),
+		})
+	}
+	// A single line longer than one message is split as escaped text.
+	fields = append(fields, model.NotificationField{Value: strings.Repeat("x<y ", 1500)})
+
+	if err := bot.Send(context.Background(), model.Notification{Title: "Digest", Fields: fields}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(stub.calls) < 3 {
+		t.Fatalf("got %d messages, want the notification split", len(stub.calls))
+	}
+
+	var all strings.Builder
+	for i, call := range stub.calls {
+		text, _ := call.Payload["text"].(string)
+		if n := textLength(text); n > maxMessageLength {
+			t.Errorf("message %d has %d code units", i, n)
+		}
+		if strings.Count(text, "<b>") != strings.Count(text, "</b>") || strings.Count(text, "<a ") != strings.Count(text, "</a>") {
+			t.Errorf("message %d has unbalanced tags", i)
+		}
+		// Every & in escaped HTML opens an entity, so one after the last ; was cut short.
+		if strings.LastIndex(text, "&") > strings.LastIndex(text, ";") {
+			t.Errorf("message %d ends inside an entity", i)
+		}
+		all.WriteString(text)
+	}
+	for i := range 12 {
+		if !strings.Contains(all.String(), fmt.Sprintf("<b>Section %d</b>", i)) {
+			t.Errorf("section %d missing", i)
+		}
+	}
+	if got := strings.Count(all.String(), "x&lt;y"); got != 1500 {
+		t.Errorf("long line kept %d of 1500 pieces", got)
+	}
+}
+
+func TestSendReportsFailingChatWithoutStoppingOthers(t *testing.T) {
+	stub := &stubBotAPI{failChats: map[string]bool{"100": true}}
+	bot := newTestBot(t, stub, "100", "200")
+
+	err := bot.Send(context.Background(), model.Notification{Title: "t"})
+	if err == nil || !strings.Contains(err.Error(), "chat 100") || !strings.Contains(err.Error(), "chat not found") {
+		t.Errorf("err = %v, want the failing chat", err)
+	}
+	if strings.Contains(fmt.Sprint(err), "TOKEN") {
+		t.Error("error leaks the bot token")
+	}
+	last := stub.calls[len(stub.calls)-1]
+	if last.Payload["chat_id"] != "200" {
+		t.Errorf("chat 200 was not served after chat 100 failed")
+	}
+}
@@ -0,0 +1,154 @@
+package usage
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"bot-viethoang/internal/adapter/filestore"
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+const (
+	dayKeyPrefix = "usage-"
+	dayLayout    = "2006-01-02"
+	monthLayout  = "2006-01"
+)
+
+// metrics is published on /debug/vars when the metrics server is enabled.
+var metrics = expvar.NewMap("llm")
+
+type dayUsage struct {
+	Date            string           `json:"date"`
+	PromptTokens    int              `json:"prompt_tokens"`
+	CandidateTokens int              `json:"candidate_tokens"`
+	TotalTokens     int              `json:"total_tokens"`
+	Calls           []model.LLMUsage `json:"calls"`
+}
+
+// Tracker persists LLM usage per day and enforces optional daily and monthly token budgets.
+type Tracker struct {
+	mu            sync.Mutex
+	store         *filestore.Store
+	dailyBudget   int
+	monthlyBudget int
+	logger        ports.Logger
+	now           func() time.Time
+}
+
+var _ ports.UsageTracker = (*Tracker)(nil)
+
+// NewTracker builds a Tracker. A budget of 0 disables that limit.
+func NewTracker(store *filestore.Store, dailyBudget, monthlyBudget int, logger ports.Logger) *Tracker {
+	return &Tracker{
+		store:         store,
+		dailyBudget:   dailyBudget,
+		monthlyBudget: monthlyBudget,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// Allow checks today's and this month's totals against the budgets.
+func (t *Tracker) Allow(ctx context.Context) error {
+	if t.dailyBudget <= 0 && t.monthlyBudget <= 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if t.dailyBudget > 0 {
+		day, err := t.loadDay(now.Format(dayLayout))
+		if err != nil {
+			return err
+		}
+		if day.TotalTokens >= t.dailyBudget {
+			return fmt.Errorf("%w: %d of %d daily tokens used", ports.ErrBudgetExceeded, day.TotalTokens, t.dailyBudget)
+		}
+	}
+
+	if t.monthlyBudget > 0 {
+		used, err := t.monthTotal(now.Format(monthLayout))
+		if err != nil {
+			return err
+		}
+		if used >= t.monthlyBudget {
+			return fmt.Errorf("%w: %d of %d monthly tokens used", ports.ErrBudgetExceeded, used, t.monthlyBudget)
+		}
+	}
+
+	return nil
+}
+
+// Record appends the call to today's usage file, updates metrics and logs the running totals.
+func (t *Tracker) Record(ctx context.Context, call model.LLMUsage) error {
+	if call.At.IsZero() {
+		call.At = t.now()
+	}
+
+	metrics.Add("calls", 1)
+	metrics.Add("prompt_tokens", int64(call.PromptTokens))
+	metrics.Add("candidate_tokens", int64(call.CandidateTokens))
+	metrics.Add("total_tokens", int64(call.TotalTokens))
+	metrics.Add("latency_ms", call.Latency.Milliseconds())
+	metrics.Add("total_tokens:"+call.Model, int64(call.TotalTokens))
+	if meter := ports.UsageMeterFrom(ctx); meter != nil {
+		meter.Add(call)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	day, err := t.loadDay(call.At.Format(dayLayout))
+	if err != nil {
+		return err
+	}
+	day.Calls = append(day.Calls, call)
+	day.PromptTokens += call.PromptTokens
+	day.CandidateTokens += call.CandidateTokens
+	day.TotalTokens += call.TotalTokens
+
+	if t.logger != nil {
+		t.logger.Info(ctx, "llm usage recorded",
+			"backend", call.Backend,
+			"model", call.Model,
+			"operation", call.Operation,
+			"promptTokens", call.PromptTokens,
+			"candidateTokens", call.CandidateTokens,
+			"totalTokens", call.TotalTokens,
+			"latency", call.Latency,
+			"dayTotalTokens", day.TotalTokens)
+	}
+
+	return t.store.Save(dayKeyPrefix+day.Date, day)
+}
+
+func (t *Tracker) loadDay(date string) (dayUsage, error) {
+	day := dayUsage{Date: date}
+	if _, err := t.store.Load(dayKeyPrefix+date, &day); err != nil {
+		return dayUsage{}, err
+	}
+	return day, nil
+}
+
+func (t *Tracker) monthTotal(month string) (int, error) {
+	keys, err := t.store.Keys(dayKeyPrefix + month)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, key := range keys {
+		day, err := t.loadDay(strings.TrimPrefix(key, dayKeyPrefix))
+		if err != nil {
+			return 0, err
+		}
+		total += day.TotalTokens
+	}
+	return total, nil
+}
@@ -0,0 +1,43 @@
+package usage
+
+import (
+	"context"
+	"testing"
+
+	"bot-viethoang/internal/adapter/filestore"
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+func TestRecordCountsCallsOnTheContextMeterOnly(t *testing.T) {
+	store, err := filestore.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("filestore.New: %v", err)
+	}
+	tracker := NewTracker(store, 0, 0, nil)
+
+	digestCtx, digest := ports.WithUsageMeter(context.Background())
+	recapCtx, recap := ports.WithUsageMeter(context.Background())
+
+	calls := []struct {
+		ctx    context.Context
+		tokens int
+	}{
+		{digestCtx, 100},
+		{recapCtx, 40},
+		{context.Background(), 7},
+		{digestCtx, 20},
+	}
+	for _, call := range calls {
+		if err := tracker.Record(call.ctx, model.LLMUsage{PromptTokens: call.tokens / 2, TotalTokens: call.tokens}); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	if got := digest.Totals(); got.Calls != 2 || got.TotalTokens != 120 || got.PromptTokens != 60 {
+		t.Errorf("digest meter = %+v, want 2 calls and 120 tokens", got)
+	}
+	if got := recap.Totals(); got.Calls != 1 || got.TotalTokens != 40 {
+		t.Errorf("recap meter = %+v, want 1 call and 40 tokens", got)
+	}
+}
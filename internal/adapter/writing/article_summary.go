@@ -0,0 +1,35 @@
+package writing
+
+import (
+	"fmt"
+	"strings"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+func parseArticleSummary(text string) (model.ArticleSummary, error) {
+	var summary model.ArticleSummary
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimLeft(strings.TrimSpace(line), "*- ")
+		switch {
+		case hasLabel(line, "TLDR:"), hasLabel(line, "TL;DR:"):
+			summary.TLDR = labelValue(line)
+		case hasLabel(line, "WHY:"):
+			summary.Relevance = labelValue(line)
+		}
+	}
+
+	if summary.TLDR == "" {
+		return model.ArticleSummary{}, fmt.Errorf("article summary missing TLDR line")
+	}
+	return summary, nil
+}
+
+func hasLabel(line, label string) bool {
+	return len(line) >= len(label) && strings.EqualFold(line[:len(label)], label)
+}
+
+func labelValue(line string) string {
+	value := line[strings.Index(line, ":")+1:]
+	return strings.TrimSpace(strings.Trim(value, "* "))
+}
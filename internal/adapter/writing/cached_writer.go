@@ -0,0 +1,221 @@
+package writing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"bot-viethoang/internal/adapter/filestore"
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+type cacheEntry struct {
+	CreatedAt time.Time       `json:"created_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// CachedWriter stores writer output on disk so restarts on the same day reuse it instead of
+// calling the LLM again. Keys hash the writer identity, the date and every input the prompt
+// mentions, so an insight is only reused for the same problems and articles. Expired entries are
+// deleted whenever a new one is stored.
+type CachedWriter struct {
+	inner     ports.ArticleWriter
+	store     *filestore.Store
+	identity  string
+	ttl       time.Duration
+	notBefore time.Time
+	logger    ports.Logger
+	now       func() time.Time
+}
+
+var _ ports.ArticleWriter = (*CachedWriter)(nil)
+
+// NewCachedWriter wraps inner. identity should name the backend and model(s). When regenerate
+// is set, entries written before this process started are ignored and replaced.
+func NewCachedWriter(inner ports.ArticleWriter, store *filestore.Store, identity string, ttl time.Duration, regenerate bool, logger ports.Logger) *CachedWriter {
+	c := &CachedWriter{
+		inner:    inner,
+		store:    store,
+		identity: identity,
+		ttl:      ttl,
+		logger:   logger,
+		now:      time.Now,
+	}
+	if regenerate {
+		c.notBefore = c.now()
+	}
+	return c
+}
+
+// Compose returns the cached insight for the same problems and articles or generates and stores a
+// new one.
+func (c *CachedWriter) Compose(ctx context.Context, daily *model.Problem, random []model.Problem, articles []model.Article) (model.Insight, error) {
+	parts := []string{problemKey(daily)}
+	for i := range random {
+		parts = append(parts, "random:"+problemKey(&random[i]))
+	}
+	for _, article := range articles {
+		parts = append(parts, "article:"+article.Link)
+	}
+	key := c.key("compose", parts...)
+
+	var insight model.Insight
+	if c.load(ctx, key, &insight) {
+		return insight, nil
+	}
+
+	insight, err := c.inner.Compose(ctx, daily, random, articles)
+	if err != nil {
+		return model.Insight{}, err
+	}
+	c.save(ctx, key, insight)
+	return insight, nil
+}
+
+// SummarizeArticle returns the cached summary for the article or generates and stores a new one.
+func (c *CachedWriter) SummarizeArticle(ctx context.Context, daily *model.Problem, article model.Article) (model.ArticleSummary, error) {
+	key := c.key("summary", problemKey(daily), article.Link)
+
+	var summary model.ArticleSummary
+	if c.load(ctx, key, &summary) {
+		return summary, nil
+	}
+
+	summary, err := c.inner.SummarizeArticle(ctx, daily, article)
+	if err != nil {
+		return model.ArticleSummary{}, err
+	}
+	c.save(ctx, key, summary)
+	return summary, nil
+}
+
+// WriteHints returns the cached hint ladder for today's daily problem or generates and stores a new one.
+func (c *CachedWriter) WriteHints(ctx context.Context, daily *model.Problem) (model.HintLadder, error) {
+	key := c.key("hints", problemKey(daily))
+
+	var ladder model.HintLadder
+	if c.load(ctx, key, &ladder) {
+		return ladder, nil
+	}
+
+	ladder, err := c.inner.WriteHints(ctx, daily)
+	if err != nil {
+		return model.HintLadder{}, err
+	}
+	c.save(ctx, key, ladder)
+	return ladder, nil
+}
+
+// WriteQuiz returns the cached quiz for today's daily problem or generates and stores a new one.
+func (c *CachedWriter) WriteQuiz(ctx context.Context, daily *model.Problem) (model.Quiz, error) {
+	key := c.key("quiz", problemKey(daily))
+
+	var quiz model.Quiz
+	if c.load(ctx, key, &quiz) {
+		return quiz, nil
+	}
+
+	quiz, err := c.inner.WriteQuiz(ctx, daily)
+	if err != nil {
+		return model.Quiz{}, err
+	}
+	c.save(ctx, key, quiz)
+	return quiz, nil
+}
+
+// WriteRecap returns the cached recap for the same set of runs or generates and stores a new one.
+func (c *CachedWriter) WriteRecap(ctx context.Context, runs []model.DailyRun) (model.WeeklyRecap, error) {
+	parts := make([]string, 0, len(runs))
+	for _, run := range runs {
+		parts = append(parts, run.Date+"/"+problemKey(run.Daily))
+	}
+	key := c.key("recap", parts...)
+
+	var recap model.WeeklyRecap
+	if c.load(ctx, key, &recap) {
+		return recap, nil
+	}
+
+	recap, err := c.inner.WriteRecap(ctx, runs)
+	if err != nil {
+		return model.WeeklyRecap{}, err
+	}
+	c.save(ctx, key, recap)
+	return recap, nil
+}
+
+func (c *CachedWriter) key(kind string, parts ...string) string {
+	hash := sha256.New()
+	for _, part := range append([]string{kind, c.identity, c.now().Format("2006-01-02")}, parts...) {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return kind + "-" + hex.EncodeToString(hash.Sum(nil))[:32]
+}
+
+func (c *CachedWriter) load(ctx context.Context, key string, v any) bool {
+	var entry cacheEntry
+	found, err := c.store.Load(key, &entry)
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Error(ctx, "insight cache read failed", "key", key, "error", err)
+		}
+		return false
+	}
+	if !found || c.now().After(entry.ExpiresAt) || entry.CreatedAt.Before(c.notBefore) {
+		return false
+	}
+	if err := json.Unmarshal(entry.Value, v); err != nil {
+		return false
+	}
+	if c.logger != nil {
+		c.logger.Info(ctx, "insight cache hit", "key", key, "createdAt", entry.CreatedAt)
+	}
+	return true
+}
+
+func (c *CachedWriter) save(ctx context.Context, key string, v any) {
+	value, err := json.Marshal(v)
+	if err == nil {
+		now := c.now()
+		err = c.store.Save(key, cacheEntry{CreatedAt: now, ExpiresAt: now.Add(c.ttl), Value: value})
+	}
+	if err != nil && c.logger != nil {
+		c.logger.Error(ctx, "insight cache write failed", "key", key, "error", err)
+	}
+	c.prune(ctx)
+}
+
+// prune deletes expired entries so the cache directory does not grow with every day's keys.
+func (c *CachedWriter) prune(ctx context.Context) {
+	keys, err := c.store.Keys("")
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Error(ctx, "insight cache prune failed", "error", err)
+		}
+		return
+	}
+
+	now := c.now()
+	for _, key := range keys {
+		var entry cacheEntry
+		if found, err := c.store.Load(key, &entry); err != nil || !found || !now.After(entry.ExpiresAt) {
+			continue
+		}
+		if err := c.store.Delete(key); err != nil && c.logger != nil {
+			c.logger.Error(ctx, "insight cache prune failed", "key", key, "error", err)
+		}
+	}
+}
+
+func problemKey(p *model.Problem) string {
+	if p == nil {
+		return ""
+	}
+	content := sha256.Sum256([]byte(p.Content))
+	return p.Slug + "|" + p.Title + "|" + hex.EncodeToString(content[:8])
+}
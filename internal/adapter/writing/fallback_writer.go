@@ -0,0 +1,117 @@
+package writing
+
+import (
+	"context"
+	"fmt"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// FallbackWriter tries several writer backends in order, e.g. Gemini first and a local model last.
+type FallbackWriter struct {
+	logger  ports.Logger
+	writers []ports.ArticleWriter
+}
+
+var _ ports.ArticleWriter = (*FallbackWriter)(nil)
+
+// NewFallbackWriter constructs a writer chain, skipping nil writers.
+func NewFallbackWriter(logger ports.Logger, writers ...ports.ArticleWriter) *FallbackWriter {
+	active := make([]ports.ArticleWriter, 0, len(writers))
+	for _, w := range writers {
+		if w != nil {
+			active = append(active, w)
+		}
+	}
+	return &FallbackWriter{
+		logger:  logger,
+		writers: active,
+	}
+}
+
+// Compose returns the first successful composition in the chain.
+func (f *FallbackWriter) Compose(ctx context.Context, daily *model.Problem, random []model.Problem, articles []model.Article) (model.Insight, error) {
+	var lastErr error
+	for idx, writer := range f.writers {
+		insight, err := writer.Compose(ctx, daily, random, articles)
+		if err == nil {
+			if idx > 0 && f.logger != nil {
+				f.logger.Info(ctx, "writer fallback succeeded", "backend", fmt.Sprintf("%T", writer))
+			}
+			return insight, nil
+		}
+		lastErr = err
+		if f.logger != nil {
+			f.logger.Error(ctx, "writer backend failed", "backend", fmt.Sprintf("%T", writer), "error", err)
+		}
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no writer backends configured")
+	}
+	return model.Insight{}, lastErr
+}
+
+// SummarizeArticle returns the first successful summary in the chain.
+func (f *FallbackWriter) SummarizeArticle(ctx context.Context, daily *model.Problem, article model.Article) (model.ArticleSummary, error) {
+	var lastErr error
+	for _, writer := range f.writers {
+		summary, err := writer.SummarizeArticle(ctx, daily, article)
+		if err == nil {
+			return summary, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no writer backends configured")
+	}
+	return model.ArticleSummary{}, lastErr
+}
+
+// WriteHints returns the first successful hint ladder in the chain.
+func (f *FallbackWriter) WriteHints(ctx context.Context, daily *model.Problem) (model.HintLadder, error) {
+	var lastErr error
+	for _, writer := range f.writers {
+		ladder, err := writer.WriteHints(ctx, daily)
+		if err == nil {
+			return ladder, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no writer backends configured")
+	}
+	return model.HintLadder{}, lastErr
+}
+
+// WriteQuiz returns the first successful quiz in the chain.
+func (f *FallbackWriter) WriteQuiz(ctx context.Context, daily *model.Problem) (model.Quiz, error) {
+	var lastErr error
+	for _, writer := range f.writers {
+		quiz, err := writer.WriteQuiz(ctx, daily)
+		if err == nil {
+			return quiz, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no writer backends configured")
+	}
+	return model.Quiz{}, lastErr
+}
+
+// WriteRecap returns the first successful weekly recap in the chain.
+func (f *FallbackWriter) WriteRecap(ctx context.Context, runs []model.DailyRun) (model.WeeklyRecap, error) {
+	var lastErr error
+	for _, writer := range f.writers {
+		recap, err := writer.WriteRecap(ctx, runs)
+		if err == nil {
+			return recap, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no writer backends configured")
+	}
+	return model.WeeklyRecap{}, lastErr
+}
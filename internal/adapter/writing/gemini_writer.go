@@ -10,29 +10,56 @@ import (
 	"strings"
 	"time"
 
+	"bot-viethoang/internal/adapter/gemini"
 	"bot-viethoang/internal/domain/model"
 	"bot-viethoang/internal/domain/ports"
 )
 
 const (
-	geminiWriteEndpointTemplate = "https://generativelanguage.googleapis.com/v1/models/%s:generateContent?key=%s"
-	maxDiscordDescription       = 2000
+	geminiWriteEndpointTemplate = "https://generativelanguage.googleapis.com/v1/models/%s:generateContent"
+	digestMaxOutputTokens       = 2500
+	summaryMaxOutputTokens      = 600
+	maxOutputTokensCeiling      = 8192
 )
 
 // GeminiWriter composes academic-style insights using Gemini.
 type GeminiWriter struct {
 	httpClient *http.Client
-	apiKey     string
-	model      string
+	endpoint   string
+	keys       *gemini.KeyPool
+	models     []string
+	backoff    time.Duration
+	prompts    *Prompts
+	validator  *Validator
+	usage      ports.UsageTracker
 	logger     ports.Logger
 }
 
-// NewGeminiWriter constructs a GeminiWriter.
-func NewGeminiWriter(apiKey, model string, timeout time.Duration, logger ports.Logger) *GeminiWriter {
+type generation struct {
+	text            string
+	status          int
+	finishReason    string
+	promptTokens    int
+	candidateTokens int
+	totalTokens     int
+	quota           bool
+	retryAfter      time.Duration
+}
+
+// NewGeminiWriter constructs a GeminiWriter that tries models in order, waiting backoff
+// (doubled on each further attempt) between retries, and rotates through keys on quota errors.
+// Blank and repeated models are dropped. validator and usage may be nil to skip output validation
+// and token accounting.
+func NewGeminiWriter(keys *gemini.KeyPool, models []string, prompts *Prompts, validator *Validator, backoff, timeout time.Duration, usage ports.UsageTracker, logger ports.Logger) *GeminiWriter {
 	return &GeminiWriter{
 		httpClient: &http.Client{Timeout: timeout},
-		apiKey:     apiKey,
-		model:      model,
+		endpoint:   geminiWriteEndpointTemplate,
+		keys:       keys,
+		models:     uniqueModels(models),
+		backoff:    backoff,
+		prompts:    prompts,
+		validator:  validator,
+		usage:      usage,
 		logger:     logger,
 	}
 }
@@ -40,33 +67,179 @@ func NewGeminiWriter(apiKey, model string, timeout time.Duration, logger ports.L
 // var _ ports.ArticleWriter = (*GeminiWriter)(nil)
 
 // Compose generates a structured narrative referencing problems and articles.
-func (g *GeminiWriter) Compose(ctx context.Context, daily *model.Problem, random []model.Problem, articles []model.Article) (string, error) {
-	if g.apiKey == "" || g.model == "" {
-		return "", fmt.Errorf("gemini writer not configured")
+func (g *GeminiWriter) Compose(ctx context.Context, daily *model.Problem, random []model.Problem, articles []model.Article) (model.Insight, error) {
+	if g.keys.Len() == 0 || len(g.models) == 0 {
+		return model.Insight{}, fmt.Errorf("gemini writer not configured")
+	}
+
+	data := PromptData{Daily: daily, Random: random, Articles: articles}
+	return composeValidated(ctx, g.prompts, g.validator, data, g.logger, func(ctx context.Context, prompt string) (string, error) {
+		return g.generateWithFallback(ctx, promptDigest, prompt, digestMaxOutputTokens, insightSchema(true))
+	})
+}
+
+// SummarizeArticle writes a two-sentence TL;DR and a relevance line for one article.
+func (g *GeminiWriter) SummarizeArticle(ctx context.Context, daily *model.Problem, article model.Article) (model.ArticleSummary, error) {
+	if g.keys.Len() == 0 || len(g.models) == 0 {
+		return model.ArticleSummary{}, fmt.Errorf("gemini writer not configured")
+	}
+
+	prompt, err := g.prompts.Render(promptArticleSummary, PromptData{Daily: daily, Article: &article})
+	if err != nil {
+		return model.ArticleSummary{}, err
+	}
+
+	text, err := g.generateWithFallback(ctx, promptArticleSummary, prompt, summaryMaxOutputTokens, nil)
+	if err != nil {
+		return model.ArticleSummary{}, err
+	}
+
+	return parseArticleSummary(text)
+}
+
+// WriteHints produces a progressive hint ladder for the daily problem.
+func (g *GeminiWriter) WriteHints(ctx context.Context, daily *model.Problem) (model.HintLadder, error) {
+	if g.keys.Len() == 0 || len(g.models) == 0 {
+		return model.HintLadder{}, fmt.Errorf("gemini writer not configured")
+	}
+
+	prompt, err := g.prompts.Render(promptHints, PromptData{Daily: daily})
+	if err != nil {
+		return model.HintLadder{}, err
+	}
+
+	text, err := g.generateWithFallback(ctx, promptHints, prompt, digestMaxOutputTokens, hintSchema(true))
+	if err != nil {
+		return model.HintLadder{}, err
+	}
+	return parseHintLadder(text)
+}
+
+// WriteQuiz produces a multiple-choice question about the daily problem.
+func (g *GeminiWriter) WriteQuiz(ctx context.Context, daily *model.Problem) (model.Quiz, error) {
+	if g.keys.Len() == 0 || len(g.models) == 0 {
+		return model.Quiz{}, fmt.Errorf("gemini writer not configured")
+	}
+
+	prompt, err := g.prompts.Render(promptQuiz, PromptData{Daily: daily})
+	if err != nil {
+		return model.Quiz{}, err
+	}
+
+	text, err := g.generateWithFallback(ctx, promptQuiz, prompt, summaryMaxOutputTokens*2, quizSchema(true))
+	if err != nil {
+		return model.Quiz{}, err
 	}
+	return parseQuiz(text)
+}
 
-	prompt := g.buildPrompt(daily, random, articles)
+// WriteRecap reviews the problems posted during the week.
+func (g *GeminiWriter) WriteRecap(ctx context.Context, runs []model.DailyRun) (model.WeeklyRecap, error) {
+	if g.keys.Len() == 0 || len(g.models) == 0 {
+		return model.WeeklyRecap{}, fmt.Errorf("gemini writer not configured")
+	}
+
+	prompt, err := g.prompts.Render(promptRecap, PromptData{Runs: runs})
+	if err != nil {
+		return model.WeeklyRecap{}, err
+	}
 
-	body, err := g.buildRequestBody(prompt)
+	text, err := g.generateWithFallback(ctx, promptRecap, prompt, digestMaxOutputTokens, recapSchema(true))
 	if err != nil {
-		return "", err
+		return model.WeeklyRecap{}, err
 	}
+	return parseRecap(text)
+}
 
-	modelsToTry := []string{g.model}
+// generateWithFallback walks the model chain. Retryable failures, including answers that came back
+// empty, blocked or still truncated at the output ceiling, move on to the next model after a
+// backoff; a MAX_TOKENS finish retries the same model with a doubled output budget.
+// Key cooldowns are per model, so a model whose keys are all cooling down is skipped and the
+// chain goes on with the next one. Only real failures grow the backoff; key rotations and budget
+// doublings retry at once.
+func (g *GeminiWriter) generateWithFallback(ctx context.Context, operation, prompt string, maxTokens int, schema map[string]any) (string, error) {
 	var lastErr error
-	for idx, modelName := range modelsToTry {
-		text, status, err := g.generate(ctx, modelName, body)
-		if err == nil {
-			if idx > 0 && g.logger != nil {
-				g.logger.Info(ctx, "gemini writer fallback succeeded", "model", modelName)
+	attempts, failures := 0, 0
+	backoffDue := false
+
+	for idx, modelName := range g.models {
+		budget := maxTokens
+		for {
+			if backoffDue {
+				if err := g.wait(ctx, failures); err != nil {
+					return "", err
+				}
+				backoffDue = false
 			}
-			return trimText(text, maxDiscordDescription), nil
-		}
-		lastErr = err
-		if status == http.StatusNotFound || status == http.StatusTooManyRequests {
-			continue
+			attempts++
+
+			// Every attempt spends tokens, so the budget is checked before each one.
+			if g.usage != nil {
+				if err := g.usage.Allow(ctx); err != nil {
+					return "", err
+				}
+			}
+
+			body, err := g.buildRequestBody(prompt, budget, schema)
+			if err != nil {
+				return "", err
+			}
+
+			key, err := g.keys.Acquire(modelName)
+			if err != nil {
+				if lastErr != nil {
+					err = fmt.Errorf("%w (last error: %v)", err, lastErr)
+				}
+				lastErr = err
+				if g.logger != nil {
+					g.logger.Info(ctx, "no gemini key available for model, skipping", "model", modelName, "error", err)
+				}
+				break
+			}
+
+			started := time.Now()
+			result, err := g.generate(ctx, modelName, key.Value, body)
+			g.recordUsage(ctx, operation, modelName, result, time.Since(started))
+			if result.quota {
+				rotated := g.keys.ReportQuota(key, result.retryAfter)
+				if g.logger != nil {
+					g.logger.Error(ctx, "gemini key hit quota", "key", key.ID, "model", modelName, "rotated", rotated, "keys", g.keys.Health())
+				}
+				if rotated {
+					lastErr = nil
+					continue
+				}
+			}
+			if result.finishReason == "MAX_TOKENS" && budget < maxOutputTokensCeiling {
+				budget = min(budget*2, maxOutputTokensCeiling)
+				lastErr = nil
+				if g.logger != nil {
+					g.logger.Info(ctx, "gemini hit token limit, retrying with larger budget", "model", modelName, "maxOutputTokens", budget)
+				}
+				continue
+			}
+			if err == nil && result.finishReason != "" && result.finishReason != "STOP" {
+				err = fmt.Errorf("gemini writer stopped early: finish reason %s", result.finishReason)
+			}
+
+			if err == nil {
+				if g.logger != nil {
+					g.logger.Info(ctx, "gemini writer produced text", "model", modelName, "fallback", idx > 0, "attempts", attempts)
+				}
+				return result.text, nil
+			}
+
+			lastErr = err
+			if !result.retryable() {
+				return "", lastErr
+			}
+			failures++
+			backoffDue = true
+			if g.logger != nil && idx < len(g.models)-1 {
+				g.logger.Error(ctx, "gemini model failed, trying next", "model", modelName, "status", result.status, "error", err)
+			}
+			break
 		}
-		break
 	}
 
 	if lastErr == nil {
@@ -75,45 +248,80 @@ func (g *GeminiWriter) Compose(ctx context.Context, daily *model.Problem, random
 	return "", lastErr
 }
 
-func (g *GeminiWriter) buildPrompt(daily *model.Problem, random []model.Problem, articles []model.Article) string {
-	var builder strings.Builder
-	builder.WriteString("Write Vietnamese algorithm notes with this exact structure:\n\n")
-	builder.WriteString("## 🎯 **Phân Tích Bài Toán**\n")
-	builder.WriteString("Main approach + why effective (2 sentences)\n\n")
-	builder.WriteString("## 📚 **Concept từ Grokking Algorithms**\n")
-	builder.WriteString("Quote concept + connection to problem (2 sentences)\n\n")
-	builder.WriteString("## 💡 **Study Plan**\n")
-	builder.WriteString("2-3 practice steps\n\n")
-	builder.WriteString("Rules: English tech terms, Markdown links, max 600 words total.\n\n")
-
-	if daily != nil {
-		builder.WriteString("Daily LeetCode Challenge:\n")
-		builder.WriteString(fmt.Sprintf("- %s (%s) – %s\n", daily.Title, daily.Difficulty, daily.Link))
-		if len(daily.Topics) > 0 {
-			builder.WriteString(fmt.Sprintf("  Topics: %s\n", strings.Join(daily.Topics, ", ")))
-		}
+func (g *GeminiWriter) recordUsage(ctx context.Context, operation, modelName string, result generation, latency time.Duration) {
+	if g.usage == nil || result.status == 0 {
+		return
 	}
-
-	if len(random) > 0 {
-		builder.WriteString("\nAdditional Practice Problems:\n")
-		for _, p := range random {
-			builder.WriteString(fmt.Sprintf("- %s (%s) – %s\n", p.Title, p.Difficulty, p.Link))
-		}
+	err := g.usage.Record(ctx, model.LLMUsage{
+		Backend:         "gemini",
+		Model:           modelName,
+		Operation:       operation,
+		PromptTokens:    result.promptTokens,
+		CandidateTokens: result.candidateTokens,
+		TotalTokens:     result.totalTokens,
+		Latency:         latency,
+	})
+	if err != nil && g.logger != nil {
+		g.logger.Error(ctx, "record llm usage failed", "error", err)
 	}
+}
 
-	if len(articles) > 0 {
-		builder.WriteString("\nBackground Reading:\n")
-		for _, a := range articles {
-			builder.WriteString(fmt.Sprintf("- %s – %s\n", a.Title, a.Link))
-		}
+// wait sleeps before the next attempt, doubling the backoff with every failure so far.
+func (g *GeminiWriter) wait(ctx context.Context, failures int) error {
+	if g.backoff <= 0 || failures <= 0 {
+		return nil
+	}
+	delay := g.backoff << (failures - 1)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
 	}
+}
 
-	builder.WriteString("\nWrite the 3 sections above. Be concise.\n")
-	return builder.String()
+// retryable reports whether another model may succeed where this call failed. A failed call that
+// still returned 2xx was empty, blocked or cut off, which a different model can often avoid.
+func (r generation) retryable() bool {
+	return (r.status >= 200 && r.status < 300) || isRetryableStatus(r.status)
 }
 
-func (g *GeminiWriter) buildRequestBody(prompt string) ([]byte, error) {
+// isRetryableStatus reports whether another model may succeed where this call failed.
+// Status 0 means the request never got a response.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case 0, http.StatusNotFound, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// buildRequestBody encodes a generateContent request with the system instruction; a non-nil
+// schema switches Gemini to JSON mode.
+func (g *GeminiWriter) buildRequestBody(prompt string, maxTokens int, schema map[string]any) ([]byte, error) {
+	generationConfig := map[string]any{
+		"temperature":     0.3,
+		"topP":            0.8,
+		"maxOutputTokens": maxTokens,
+	}
+	if schema != nil {
+		generationConfig["responseMimeType"] = "application/json"
+		generationConfig["responseSchema"] = schema
+	}
+
+	system, err := g.prompts.System()
+	if err != nil {
+		return nil, err
+	}
+
 	payload := map[string]any{
+		"systemInstruction": map[string]any{
+			"parts": []map[string]string{
+				{"text": system},
+			},
+		},
 		"contents": []map[string]any{
 			{
 				"parts": []map[string]string{
@@ -121,11 +329,7 @@ func (g *GeminiWriter) buildRequestBody(prompt string) ([]byte, error) {
 				},
 			},
 		},
-		"generationConfig": map[string]any{
-			"temperature":     0.3,
-			"topP":            0.8,
-			"maxOutputTokens": 2500,
-		},
+		"generationConfig": generationConfig,
 		"safetySettings": []map[string]any{
 			{"category": "HARM_CATEGORY_HARASSMENT", "threshold": "BLOCK_NONE"},
 			{"category": "HARM_CATEGORY_HATE_SPEECH", "threshold": "BLOCK_NONE"},
@@ -141,37 +345,41 @@ func (g *GeminiWriter) buildRequestBody(prompt string) ([]byte, error) {
 	return body, nil
 }
 
-func (g *GeminiWriter) generate(ctx context.Context, model string, body []byte) (string, int, error) {
-	endpoint := fmt.Sprintf(geminiWriteEndpointTemplate, model, g.apiKey)
+func (g *GeminiWriter) generate(ctx context.Context, model, apiKey string, body []byte) (generation, error) {
+	endpoint := fmt.Sprintf(g.endpoint, model)
 
 	// Log request details for debugging
 	if g.logger != nil {
 		g.logger.Info(ctx, "calling gemini API",
 			"model", model,
-			"endpoint", strings.Split(endpoint, "?")[0], // hide API key
+			"endpoint", endpoint,
 			"requestSize", len(body))
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
 	if err != nil {
-		return "", 0, fmt.Errorf("create gemini writer request: %w", err)
+		return generation{}, fmt.Errorf("create gemini writer request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", apiKey)
 
 	resp, err := g.httpClient.Do(req)
 	if err != nil {
-		return "", 0, fmt.Errorf("call gemini writer: %w", err)
+		return generation{}, fmt.Errorf("call gemini writer: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read full response body for better debugging
-	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024))
+	result := generation{status: resp.StatusCode}
+	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
 	if err != nil {
-		return "", resp.StatusCode, fmt.Errorf("read response body: %w", err)
+		return result, fmt.Errorf("read response body: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", resp.StatusCode, fmt.Errorf("gemini writer status %d: %s", resp.StatusCode, strings.TrimSpace(string(bodyBytes)))
+		result.quota = gemini.IsQuotaError(resp.StatusCode, bodyBytes)
+		result.retryAfter = gemini.RetryDelay(resp.Header, bodyBytes)
+		return result, fmt.Errorf("gemini writer status %d: %s", resp.StatusCode, strings.TrimSpace(string(bodyBytes)))
 	}
 
 	var payload struct {
@@ -186,13 +394,26 @@ func (g *GeminiWriter) generate(ctx context.Context, model string, body []byte)
 		PromptFeedback struct {
 			BlockReason string `json:"blockReason,omitempty"`
 		} `json:"promptFeedback,omitempty"`
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+			TotalTokenCount      int `json:"totalTokenCount"`
+		} `json:"usageMetadata"`
 	}
 
 	if err := json.Unmarshal(bodyBytes, &payload); err != nil {
 		if g.logger != nil {
 			g.logger.Error(ctx, "failed to decode gemini response", "error", err, "body", string(bodyBytes[:min(len(bodyBytes), 500)]))
 		}
-		return "", resp.StatusCode, fmt.Errorf("decode gemini writer response: %w", err)
+		return result, fmt.Errorf("decode gemini writer response: %w", err)
+	}
+
+	result.promptTokens = payload.UsageMetadata.PromptTokenCount
+	result.candidateTokens = payload.UsageMetadata.CandidatesTokenCount
+	result.totalTokens = payload.UsageMetadata.TotalTokenCount
+
+	if len(payload.Candidates) > 0 {
+		result.finishReason = payload.Candidates[0].FinishReason
 	}
 
 	// Log response structure for debugging
@@ -205,29 +426,28 @@ func (g *GeminiWriter) generate(ctx context.Context, model string, body []byte)
 			"partsCount", partsCount)
 	}
 
-	text := extractCandidateText(payload.Candidates)
-	if text == "" {
+	result.text = extractCandidateText(payload.Candidates)
+	if result.text == "" {
 		// Log full response when empty
 		if g.logger != nil {
-			finishReason := ""
-			if len(payload.Candidates) > 0 {
-				finishReason = payload.Candidates[0].FinishReason
-			}
 			g.logger.Error(ctx, "gemini returned empty text",
 				"rawResponse", string(bodyBytes[:min(len(bodyBytes), 800)]),
 				"candidatesCount", len(payload.Candidates),
-				"finishReason", finishReason)
+				"finishReason", result.finishReason)
 		}
 
 		// Better error message
-		if len(payload.Candidates) > 0 && payload.Candidates[0].FinishReason == "MAX_TOKENS" {
-			return "", resp.StatusCode, fmt.Errorf("gemini hit token limit before generating output (try increasing maxOutputTokens)")
+		if result.finishReason == "MAX_TOKENS" {
+			return result, fmt.Errorf("gemini hit token limit before generating output (try increasing maxOutputTokens)")
 		}
 
-		return "", resp.StatusCode, fmt.Errorf("gemini writer returned empty text (candidates: %d)", len(payload.Candidates))
+		if reason := payload.PromptFeedback.BlockReason; reason != "" {
+			return result, fmt.Errorf("gemini writer blocked the prompt: %s", reason)
+		}
+		return result, fmt.Errorf("gemini writer returned empty text (candidates: %d, finish reason: %s)", len(payload.Candidates), result.finishReason)
 	}
 
-	return text, resp.StatusCode, nil
+	return result, nil
 }
 
 func extractCandidateText(candidates []struct {
@@ -248,11 +468,18 @@ func extractCandidateText(candidates []struct {
 	return ""
 }
 
-func trimText(text string, max int) string {
-	if len(text) <= max {
-		return text
+func uniqueModels(models []string) []string {
+	seen := make(map[string]struct{}, len(models))
+	unique := make([]string, 0, len(models))
+	for _, name := range models {
+		name = strings.TrimSpace(name)
+		if _, dup := seen[name]; name == "" || dup {
+			continue
+		}
+		seen[name] = struct{}{}
+		unique = append(unique, name)
 	}
-	return strings.TrimSpace(text[:max-3]) + "..."
+	return unique
 }
 
 func min(a, b int) int {
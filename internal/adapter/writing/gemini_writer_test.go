@@ -0,0 +1,100 @@
+package writing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bot-viethoang/internal/adapter/gemini"
+)
+
+const geminiOK = `{"candidates":[{"content":{"parts":[{"text":"done"}]},"finishReason":"STOP"}],"usageMetadata":{"totalTokenCount":3}}`
+
+// stubGemini answers generateContent calls per model and records which models were called.
+type stubGemini struct {
+	mu        sync.Mutex
+	calls     []string
+	responses map[string]func(w http.ResponseWriter)
+}
+
+func (s *stubGemini) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	modelName := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), ":generateContent")
+	s.mu.Lock()
+	s.calls = append(s.calls, modelName)
+	s.mu.Unlock()
+	s.responses[modelName](w)
+}
+
+func (s *stubGemini) called() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func newTestGeminiWriter(t *testing.T, stub *stubGemini, keys *gemini.KeyPool, models ...string) *GeminiWriter {
+	t.Helper()
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	prompts, err := NewPrompts("", "", "", nil)
+	if err != nil {
+		t.Fatalf("NewPrompts: %v", err)
+	}
+	writer := NewGeminiWriter(keys, models, prompts, nil, 0, 5*time.Second, nil, nil)
+	writer.endpoint = server.URL + "/%s:generateContent"
+	return writer
+}
+
+func TestGenerateFallsBackWhenKeysAreCoolingForModel(t *testing.T) {
+	stub := &stubGemini{responses: map[string]func(http.ResponseWriter){
+		"model-a": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"status":"RESOURCE_EXHAUSTED"}}`))
+		},
+		"model-b": func(w http.ResponseWriter) {
+			_, _ = w.Write([]byte(geminiOK))
+		},
+	}}
+	keys := gemini.NewKeyPool([]string{"key-one", "key-two"}, time.Hour)
+	writer := newTestGeminiWriter(t, stub, keys, "model-a", "model-b")
+
+	// First call: both keys hit quota on model A, then model B answers.
+	text, err := writer.generateWithFallback(context.Background(), promptArticleSummary, "prompt", 100, nil)
+	if err != nil || text != "done" {
+		t.Fatalf("first call = %q, %v; want done", text, err)
+	}
+	if got := strings.Join(stub.called(), ","); got != "model-a,model-a,model-b" {
+		t.Errorf("first call hit %s", got)
+	}
+
+	// Second call: every key is still cooling for model A, so it is skipped without a request.
+	text, err = writer.generateWithFallback(context.Background(), promptArticleSummary, "prompt", 100, nil)
+	if err != nil || text != "done" {
+		t.Fatalf("second call = %q, %v; want done", text, err)
+	}
+	if got := strings.Join(stub.called()[3:], ","); got != "model-b" {
+		t.Errorf("second call hit %s", got)
+	}
+}
+
+func TestGenerateReportsErrorWhenChainIsExhausted(t *testing.T) {
+	stub := &stubGemini{responses: map[string]func(http.ResponseWriter){
+		"model-a": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+	}}
+	keys := gemini.NewKeyPool([]string{"key-one"}, time.Hour)
+	writer := newTestGeminiWriter(t, stub, keys, "model-a")
+
+	if _, err := writer.generateWithFallback(context.Background(), promptArticleSummary, "prompt", 100, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	_, err := writer.generateWithFallback(context.Background(), promptArticleSummary, "prompt", 100, nil)
+	if err == nil || !strings.Contains(err.Error(), "cooling down") {
+		t.Errorf("err = %v, want cooling down", err)
+	}
+}
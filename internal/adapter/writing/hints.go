@@ -0,0 +1,66 @@
+package writing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+const hintCount = 3
+
+// hintSchema describes the hint ladder JSON object; see insightSchema for the type casing.
+func hintSchema(upperTypes bool) map[string]any {
+	objectType, stringType, arrayType := "object", "string", "array"
+	if upperTypes {
+		objectType, stringType, arrayType = "OBJECT", "STRING", "ARRAY"
+	}
+
+	schema := map[string]any{
+		"type": objectType,
+		"properties": map[string]any{
+			"hints": map[string]any{
+				"type":  arrayType,
+				"items": map[string]any{"type": stringType},
+			},
+			"approach": map[string]any{"type": stringType},
+		},
+		"required": []string{"hints", "approach"},
+	}
+	if !upperTypes {
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+func parseHintLadder(raw string) (model.HintLadder, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "```json")
+	raw = strings.TrimPrefix(raw, "```")
+	raw = strings.TrimSuffix(raw, "```")
+
+	var payload struct {
+		Hints    []string `json:"hints"`
+		Approach string   `json:"approach"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &payload); err != nil {
+		return model.HintLadder{}, fmt.Errorf("parse hints JSON: %w", err)
+	}
+
+	ladder := model.HintLadder{Approach: strings.TrimSpace(payload.Approach)}
+	for _, hint := range payload.Hints {
+		if hint = strings.TrimSpace(hint); hint != "" {
+			ladder.Hints = append(ladder.Hints, hint)
+		}
+	}
+
+	if len(ladder.Hints) < hintCount {
+		return model.HintLadder{}, fmt.Errorf("expected %d hints, got %d", hintCount, len(ladder.Hints))
+	}
+	if ladder.Approach == "" {
+		return model.HintLadder{}, fmt.Errorf("hint ladder missing approach")
+	}
+	ladder.Hints = ladder.Hints[:hintCount]
+	return ladder, nil
+}
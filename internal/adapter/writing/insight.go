@@ -0,0 +1,78 @@
+package writing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+var insightKeys = []string{"analysis", "grokking_concept", "study_plan", "complexity"}
+
+// insightSchema describes the JSON object writers must return. Gemini expects upper-case
+// OpenAPI type names while Chat Completions servers take standard JSON Schema.
+func insightSchema(upperTypes bool) map[string]any {
+	objectType, stringType := "object", "string"
+	if upperTypes {
+		objectType, stringType = "OBJECT", "STRING"
+	}
+
+	properties := make(map[string]any, len(insightKeys))
+	for _, key := range insightKeys {
+		properties[key] = map[string]any{"type": stringType}
+	}
+
+	schema := map[string]any{
+		"type":       objectType,
+		"properties": properties,
+		"required":   insightKeys,
+	}
+	if !upperTypes {
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+// parseInsight decodes and validates a structured insight, tolerating Markdown code fences.
+func parseInsight(raw string) (model.Insight, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "```json")
+	raw = strings.TrimPrefix(raw, "```JSON")
+	raw = strings.TrimPrefix(raw, "```")
+	raw = strings.TrimSuffix(raw, "```")
+	raw = strings.TrimSpace(raw)
+
+	var payload struct {
+		Analysis   string `json:"analysis"`
+		Concept    string `json:"grokking_concept"`
+		StudyPlan  string `json:"study_plan"`
+		Complexity string `json:"complexity"`
+	}
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		return model.Insight{}, fmt.Errorf("parse insight JSON: %w", err)
+	}
+
+	insight := model.Insight{
+		Analysis:   strings.TrimSpace(payload.Analysis),
+		Concept:    strings.TrimSpace(payload.Concept),
+		StudyPlan:  strings.TrimSpace(payload.StudyPlan),
+		Complexity: strings.TrimSpace(payload.Complexity),
+	}
+
+	var missing []string
+	if insight.Analysis == "" {
+		missing = append(missing, "analysis")
+	}
+	if insight.Concept == "" {
+		missing = append(missing, "grokking_concept")
+	}
+	if insight.StudyPlan == "" {
+		missing = append(missing, "study_plan")
+	}
+	if len(missing) > 0 {
+		return model.Insight{}, fmt.Errorf("insight missing sections: %s", strings.Join(missing, ", "))
+	}
+
+	return insight, nil
+}
@@ -0,0 +1,107 @@
+package writing
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// knowledgeLimit caps how many chapters are injected into one prompt.
+const knowledgeLimit = 3
+
+//go:embed knowledge/grokking.json
+var embeddedKnowledge []byte
+
+// KnowledgeEntry is one Grokking Algorithms chapter with the LeetCode topics it covers and an
+// approved paraphrase the model may cite.
+type KnowledgeEntry struct {
+	Chapter  string   `json:"chapter"`
+	Title    string   `json:"title"`
+	Topics   []string `json:"topics"`
+	Concepts []string `json:"concepts"`
+	Excerpt  string   `json:"excerpt"`
+}
+
+// KnowledgeBase retrieves chapters by LeetCode topic tag.
+type KnowledgeBase struct {
+	entries []KnowledgeEntry
+	byTopic map[string][]int
+}
+
+// NewKnowledgeBase loads entries from path, or from the bundled file when path is empty.
+func NewKnowledgeBase(path string) (*KnowledgeBase, error) {
+	data := embeddedKnowledge
+	if path != "" {
+		custom, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read knowledge file: %w", err)
+		}
+		data = custom
+	}
+
+	var entries []KnowledgeEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("decode knowledge file: %w", err)
+	}
+
+	kb := &KnowledgeBase{entries: entries, byTopic: make(map[string][]int)}
+	for i, entry := range entries {
+		for _, topic := range entry.Topics {
+			key := strings.ToLower(strings.TrimSpace(topic))
+			kb.byTopic[key] = append(kb.byTopic[key], i)
+		}
+	}
+	return kb, nil
+}
+
+// Lookup returns up to limit entries covering the given topics, most matching topics first and
+// book order for ties.
+func (k *KnowledgeBase) Lookup(topics []string, limit int) []KnowledgeEntry {
+	if k == nil || limit <= 0 {
+		return nil
+	}
+
+	scores := make(map[int]int)
+	for _, topic := range topics {
+		for _, idx := range k.byTopic[strings.ToLower(strings.TrimSpace(topic))] {
+			scores[idx]++
+		}
+	}
+
+	matched := make([]int, 0, len(scores))
+	for idx := range scores {
+		matched = append(matched, idx)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if scores[matched[i]] != scores[matched[j]] {
+			return scores[matched[i]] > scores[matched[j]]
+		}
+		return matched[i] < matched[j]
+	})
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	entries := make([]KnowledgeEntry, 0, len(matched))
+	for _, idx := range matched {
+		entries = append(entries, k.entries[idx])
+	}
+	return entries
+}
+
+// promptTopics collects the topic tags a prompt is about: the daily problem, or every daily in a recap.
+func promptTopics(data PromptData) []string {
+	var topics []string
+	if data.Daily != nil {
+		topics = append(topics, data.Daily.Topics...)
+	}
+	for _, run := range data.Runs {
+		if run.Daily != nil {
+			topics = append(topics, run.Daily.Topics...)
+		}
+	}
+	return topics
+}
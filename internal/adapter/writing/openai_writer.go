@@ -0,0 +1,276 @@
+package writing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIWriter composes insights through any OpenAI Chat Completions compatible server
+// (OpenAI, Azure OpenAI, OpenRouter, Ollama, llama.cpp).
+type OpenAIWriter struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	model      string
+	prompts    *Prompts
+	validator  *Validator
+	usage      ports.UsageTracker
+	logger     ports.Logger
+}
+
+var _ ports.ArticleWriter = (*OpenAIWriter)(nil)
+
+// NewOpenAIWriter constructs an OpenAIWriter. apiKey may be empty for local servers; validator and
+// usage may be nil to skip output validation and token accounting.
+func NewOpenAIWriter(baseURL, apiKey, model string, prompts *Prompts, validator *Validator, timeout time.Duration, usage ports.UsageTracker, logger ports.Logger) *OpenAIWriter {
+	if strings.TrimSpace(baseURL) == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	return &OpenAIWriter{
+		httpClient: &http.Client{Timeout: timeout},
+		baseURL:    strings.TrimSpace(baseURL),
+		apiKey:     apiKey,
+		model:      model,
+		prompts:    prompts,
+		validator:  validator,
+		usage:      usage,
+		logger:     logger,
+	}
+}
+
+// Compose generates a structured narrative referencing problems and articles.
+func (o *OpenAIWriter) Compose(ctx context.Context, daily *model.Problem, random []model.Problem, articles []model.Article) (model.Insight, error) {
+	if o.model == "" {
+		return model.Insight{}, fmt.Errorf("openai writer not configured")
+	}
+
+	data := PromptData{Daily: daily, Random: random, Articles: articles}
+	return composeValidated(ctx, o.prompts, o.validator, data, o.logger, func(ctx context.Context, prompt string) (string, error) {
+		return o.complete(ctx, promptDigest, prompt, digestMaxOutputTokens, insightSchema(false))
+	})
+}
+
+// SummarizeArticle writes a two-sentence TL;DR and a relevance line for one article.
+func (o *OpenAIWriter) SummarizeArticle(ctx context.Context, daily *model.Problem, article model.Article) (model.ArticleSummary, error) {
+	if o.model == "" {
+		return model.ArticleSummary{}, fmt.Errorf("openai writer not configured")
+	}
+
+	prompt, err := o.prompts.Render(promptArticleSummary, PromptData{Daily: daily, Article: &article})
+	if err != nil {
+		return model.ArticleSummary{}, err
+	}
+
+	text, err := o.complete(ctx, promptArticleSummary, prompt, summaryMaxOutputTokens, nil)
+	if err != nil {
+		return model.ArticleSummary{}, err
+	}
+	return parseArticleSummary(text)
+}
+
+// WriteHints produces a progressive hint ladder for the daily problem.
+func (o *OpenAIWriter) WriteHints(ctx context.Context, daily *model.Problem) (model.HintLadder, error) {
+	if o.model == "" {
+		return model.HintLadder{}, fmt.Errorf("openai writer not configured")
+	}
+
+	prompt, err := o.prompts.Render(promptHints, PromptData{Daily: daily})
+	if err != nil {
+		return model.HintLadder{}, err
+	}
+
+	text, err := o.complete(ctx, promptHints, prompt, digestMaxOutputTokens, hintSchema(false))
+	if err != nil {
+		return model.HintLadder{}, err
+	}
+	return parseHintLadder(text)
+}
+
+// WriteQuiz produces a multiple-choice question about the daily problem.
+func (o *OpenAIWriter) WriteQuiz(ctx context.Context, daily *model.Problem) (model.Quiz, error) {
+	if o.model == "" {
+		return model.Quiz{}, fmt.Errorf("openai writer not configured")
+	}
+
+	prompt, err := o.prompts.Render(promptQuiz, PromptData{Daily: daily})
+	if err != nil {
+		return model.Quiz{}, err
+	}
+
+	text, err := o.complete(ctx, promptQuiz, prompt, summaryMaxOutputTokens*2, quizSchema(false))
+	if err != nil {
+		return model.Quiz{}, err
+	}
+	return parseQuiz(text)
+}
+
+// WriteRecap reviews the problems posted during the week.
+func (o *OpenAIWriter) WriteRecap(ctx context.Context, runs []model.DailyRun) (model.WeeklyRecap, error) {
+	if o.model == "" {
+		return model.WeeklyRecap{}, fmt.Errorf("openai writer not configured")
+	}
+
+	prompt, err := o.prompts.Render(promptRecap, PromptData{Runs: runs})
+	if err != nil {
+		return model.WeeklyRecap{}, err
+	}
+
+	text, err := o.complete(ctx, promptRecap, prompt, digestMaxOutputTokens, recapSchema(false))
+	if err != nil {
+		return model.WeeklyRecap{}, err
+	}
+	return parseRecap(text)
+}
+
+// complete sends one chat completion; a non-nil schema requests json_schema structured output.
+func (o *OpenAIWriter) complete(ctx context.Context, operation, prompt string, maxTokens int, schema map[string]any) (string, error) {
+	if o.usage != nil {
+		if err := o.usage.Allow(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	endpoint, err := o.endpoint()
+	if err != nil {
+		return "", err
+	}
+
+	system, err := o.prompts.System()
+	if err != nil {
+		return "", err
+	}
+
+	request := map[string]any{
+		"model": o.model,
+		"messages": []map[string]string{
+			{"role": "system", "content": system},
+			{"role": "user", "content": prompt},
+		},
+		"temperature": 0.3,
+		"top_p":       0.8,
+		"max_tokens":  maxTokens,
+	}
+	if schema != nil {
+		request["response_format"] = map[string]any{
+			"type": "json_schema",
+			"json_schema": map[string]any{
+				"name":   "response",
+				"strict": true,
+				"schema": schema,
+			},
+		}
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("marshal openai writer payload: %w", err)
+	}
+
+	if o.logger != nil {
+		o.logger.Info(ctx, "calling chat completions API",
+			"model", o.model,
+			"endpoint", strings.Split(endpoint, "?")[0],
+			"requestSize", len(body))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("create openai writer request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	o.authorize(req)
+
+	started := time.Now()
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("call openai writer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	latency := time.Since(started)
+	if err != nil {
+		return "", fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("openai writer status %d: %s", resp.StatusCode, strings.TrimSpace(string(bodyBytes[:min(len(bodyBytes), 500)])))
+	}
+
+	var payload struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+			FinishReason string `json:"finish_reason"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+			TotalTokens      int `json:"total_tokens"`
+		} `json:"usage"`
+	}
+
+	if err := json.Unmarshal(bodyBytes, &payload); err != nil {
+		return "", fmt.Errorf("decode openai writer response: %w", err)
+	}
+
+	if o.usage != nil {
+		err := o.usage.Record(ctx, model.LLMUsage{
+			Backend:         "openai",
+			Model:           o.model,
+			Operation:       operation,
+			PromptTokens:    payload.Usage.PromptTokens,
+			CandidateTokens: payload.Usage.CompletionTokens,
+			TotalTokens:     payload.Usage.TotalTokens,
+			Latency:         latency,
+		})
+		if err != nil && o.logger != nil {
+			o.logger.Error(ctx, "record llm usage failed", "error", err)
+		}
+	}
+
+	for _, choice := range payload.Choices {
+		if text := strings.TrimSpace(choice.Message.Content); text != "" {
+			return text, nil
+		}
+	}
+
+	if len(payload.Choices) > 0 && payload.Choices[0].FinishReason == "length" {
+		return "", fmt.Errorf("openai writer hit token limit before generating output")
+	}
+	return "", fmt.Errorf("openai writer returned empty text (choices: %d)", len(payload.Choices))
+}
+
+// endpoint appends /chat/completions to the base URL path, keeping any query such as Azure's api-version.
+func (o *OpenAIWriter) endpoint() (string, error) {
+	parsed, err := url.Parse(o.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("parse openai base URL: %w", err)
+	}
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/") + "/chat/completions"
+	return parsed.String(), nil
+}
+
+func (o *OpenAIWriter) authorize(req *http.Request) {
+	if o.apiKey == "" {
+		return
+	}
+	if strings.HasSuffix(req.URL.Hostname(), ".openai.azure.com") {
+		req.Header.Set("api-key", o.apiKey)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+o.apiKey)
+}
@@ -0,0 +1,159 @@
+package writing
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+const (
+	defaultPromptProfile = "default"
+
+	promptDigest         = "digest"
+	promptArticleSummary = "article_summary"
+	promptHints          = "hints"
+	promptQuiz           = "quiz"
+	promptRecap          = "recap"
+	promptSystem         = "system"
+)
+
+//go:embed templates
+var embeddedTemplates embed.FS
+
+var promptNames = []string{promptDigest, promptArticleSummary, promptHints, promptQuiz, promptRecap, promptSystem}
+
+var promptFuncs = template.FuncMap{
+	"join": strings.Join,
+	"truncate": func(text string, limit int) string {
+		runes := []rune(text)
+		if len(runes) <= limit {
+			return text
+		}
+		return string(runes[:limit])
+	},
+	// untrusted and untrustedText clean scraped fields before they go into a data block.
+	"untrusted": func(text string) string {
+		return sanitizeUntrusted(text, false)
+	},
+	"untrustedText": func(text string) string {
+		return sanitizeUntrusted(text, true)
+	},
+}
+
+// PromptData is the typed input every prompt template receives.
+type PromptData struct {
+	Daily    *model.Problem
+	Random   []model.Problem
+	Articles []model.Article
+	Article  *model.Article
+	Runs     []model.DailyRun
+	Date     time.Time
+	TeamName string
+	// Knowledge holds the Grokking Algorithms chapters retrieved for the prompt's topics.
+	Knowledge []KnowledgeEntry
+	// Feedback lists validation errors from a rejected earlier answer when re-prompting.
+	Feedback []string
+}
+
+// Prompts holds the parsed prompt templates for one profile.
+type Prompts struct {
+	profile   string
+	teamName  string
+	knowledge *KnowledgeBase
+	templates map[string]*template.Template
+}
+
+// NewPrompts loads templates for profile. Each template is looked up in dir/<profile>/, then dir/,
+// then the embedded profile and finally the embedded default, so overrides can be partial.
+// knowledge may be nil to render prompts without book references.
+func NewPrompts(dir, profile, teamName string, knowledge *KnowledgeBase) (*Prompts, error) {
+	if profile == "" {
+		profile = defaultPromptProfile
+	}
+
+	prompts := &Prompts{
+		profile:   profile,
+		teamName:  teamName,
+		knowledge: knowledge,
+		templates: make(map[string]*template.Template, len(promptNames)),
+	}
+
+	for _, name := range promptNames {
+		source, origin, err := lookupTemplate(dir, profile, name)
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := template.New(name).Funcs(promptFuncs).Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("parse prompt %s from %s: %w", name, origin, err)
+		}
+		prompts.templates[name] = tmpl
+	}
+
+	return prompts, nil
+}
+
+// Profile returns the name of the loaded prompt profile.
+func (p *Prompts) Profile() string {
+	return p.profile
+}
+
+// Render executes the named template. Date, TeamName and Knowledge are filled in when left empty.
+func (p *Prompts) Render(name string, data PromptData) (string, error) {
+	tmpl, ok := p.templates[name]
+	if !ok {
+		return "", fmt.Errorf("unknown prompt %q", name)
+	}
+	if data.Date.IsZero() {
+		data.Date = time.Now()
+	}
+	if data.TeamName == "" {
+		data.TeamName = p.teamName
+	}
+	if data.Knowledge == nil {
+		data.Knowledge = p.knowledge.Lookup(promptTopics(data), knowledgeLimit)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render prompt %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// System renders the system instruction sent alongside every prompt.
+func (p *Prompts) System() (string, error) {
+	return p.Render(promptSystem, PromptData{})
+}
+
+func lookupTemplate(dir, profile, name string) (string, string, error) {
+	file := name + ".tmpl"
+
+	if dir != "" {
+		for _, path := range []string{filepath.Join(dir, profile, file), filepath.Join(dir, file)} {
+			data, err := os.ReadFile(path)
+			if err == nil {
+				return string(data), path, nil
+			}
+			if !os.IsNotExist(err) {
+				return "", "", fmt.Errorf("read prompt %s: %w", path, err)
+			}
+		}
+	}
+
+	for _, candidate := range []string{profile, defaultPromptProfile} {
+		path := "templates/" + candidate + "/" + file
+		if data, err := embeddedTemplates.ReadFile(path); err == nil {
+			return string(data), "embedded " + path, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("prompt %s not found for profile %s", name, profile)
+}
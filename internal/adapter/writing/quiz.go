@@ -0,0 +1,93 @@
+package writing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+const (
+	quizOptionCount = 4
+	// Discord caps poll questions at 300 characters and answers at 55.
+	quizQuestionLimit = 300
+	quizOptionLimit   = 55
+)
+
+// quizSchema describes the quiz JSON object; see insightSchema for the type casing.
+func quizSchema(upperTypes bool) map[string]any {
+	objectType, stringType, arrayType, integerType := "object", "string", "array", "integer"
+	if upperTypes {
+		objectType, stringType, arrayType, integerType = "OBJECT", "STRING", "ARRAY", "INTEGER"
+	}
+
+	schema := map[string]any{
+		"type": objectType,
+		"properties": map[string]any{
+			"question": map[string]any{"type": stringType},
+			"options": map[string]any{
+				"type":  arrayType,
+				"items": map[string]any{"type": stringType},
+			},
+			"answer_index": map[string]any{"type": integerType},
+			"explanation":  map[string]any{"type": stringType},
+		},
+		"required": []string{"question", "options", "answer_index", "explanation"},
+	}
+	if !upperTypes {
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+func parseQuiz(raw string) (model.Quiz, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "```json")
+	raw = strings.TrimPrefix(raw, "```")
+	raw = strings.TrimSuffix(raw, "```")
+
+	var payload struct {
+		Question    string   `json:"question"`
+		Options     []string `json:"options"`
+		AnswerIndex int      `json:"answer_index"`
+		Explanation string   `json:"explanation"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &payload); err != nil {
+		return model.Quiz{}, fmt.Errorf("parse quiz JSON: %w", err)
+	}
+
+	quiz := model.Quiz{
+		Question:    strings.TrimSpace(payload.Question),
+		Answer:      payload.AnswerIndex,
+		Explanation: strings.TrimSpace(payload.Explanation),
+	}
+	for _, option := range payload.Options {
+		if option = strings.TrimSpace(option); option != "" {
+			quiz.Options = append(quiz.Options, trimQuizOption(option))
+		}
+	}
+
+	switch {
+	case quiz.Question == "" || utf8.RuneCountInString(quiz.Question) > quizQuestionLimit:
+		return model.Quiz{}, fmt.Errorf("quiz question must be 1-%d characters", quizQuestionLimit)
+	case len(quiz.Options) != quizOptionCount:
+		return model.Quiz{}, fmt.Errorf("expected %d quiz options, got %d", quizOptionCount, len(quiz.Options))
+	case quiz.Answer < 0 || quiz.Answer >= len(quiz.Options):
+		return model.Quiz{}, fmt.Errorf("quiz answer index %d out of range", quiz.Answer)
+	case quiz.Explanation == "":
+		return model.Quiz{}, fmt.Errorf("quiz missing explanation")
+	}
+	return quiz, nil
+}
+
+// trimQuizOption shortens an option to the poll answer limit rather than discarding the whole quiz
+// over one wordy choice.
+func trimQuizOption(option string) string {
+	runes := []rune(option)
+	if len(runes) <= quizOptionLimit {
+		return option
+	}
+	return strings.TrimSpace(string(runes[:quizOptionLimit-1])) + "…"
+}
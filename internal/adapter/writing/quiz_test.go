@@ -0,0 +1,27 @@
+package writing
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseQuizTrimsLongOptions(t *testing.T) {
+	long := strings.Repeat("Duyệt hai con trỏ ", 5)
+	answer := `{"question":"Cách nào tối ưu?","options":["` + long + `","Hash map","Sort","Brute force"],"answer_index":1,"explanation":"Tra cứu O(1)."}`
+
+	quiz, err := parseQuiz(answer)
+	if err != nil {
+		t.Fatalf("parseQuiz: %v", err)
+	}
+	option := quiz.Options[0]
+	if n := utf8.RuneCountInString(option); n > quizOptionLimit {
+		t.Errorf("option has %d runes, want at most %d", n, quizOptionLimit)
+	}
+	if !utf8.ValidString(option) || !strings.HasSuffix(option, "…") {
+		t.Errorf("option = %q, want a valid string ending in an ellipsis", option)
+	}
+	if quiz.Options[1] != "Hash map" {
+		t.Errorf("short option changed to %q", quiz.Options[1])
+	}
+}
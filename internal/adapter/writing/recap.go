@@ -0,0 +1,60 @@
+package writing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+var recapKeys = []string{"patterns", "pitfalls", "connections"}
+
+// recapSchema describes the weekly recap JSON object; see insightSchema for the type casing.
+func recapSchema(upperTypes bool) map[string]any {
+	objectType, stringType := "object", "string"
+	if upperTypes {
+		objectType, stringType = "OBJECT", "STRING"
+	}
+
+	properties := make(map[string]any, len(recapKeys))
+	for _, key := range recapKeys {
+		properties[key] = map[string]any{"type": stringType}
+	}
+
+	schema := map[string]any{
+		"type":       objectType,
+		"properties": properties,
+		"required":   recapKeys,
+	}
+	if !upperTypes {
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+func parseRecap(raw string) (model.WeeklyRecap, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "```json")
+	raw = strings.TrimPrefix(raw, "```")
+	raw = strings.TrimSuffix(raw, "```")
+
+	var payload struct {
+		Patterns    string `json:"patterns"`
+		Pitfalls    string `json:"pitfalls"`
+		Connections string `json:"connections"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(raw)), &payload); err != nil {
+		return model.WeeklyRecap{}, fmt.Errorf("parse recap JSON: %w", err)
+	}
+
+	recap := model.WeeklyRecap{
+		Patterns:    strings.TrimSpace(payload.Patterns),
+		Pitfalls:    strings.TrimSpace(payload.Pitfalls),
+		Connections: strings.TrimSpace(payload.Connections),
+	}
+	if recap.Patterns == "" || recap.Pitfalls == "" || recap.Connections == "" {
+		return model.WeeklyRecap{}, fmt.Errorf("recap missing sections")
+	}
+	return recap, nil
+}
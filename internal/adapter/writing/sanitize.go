@@ -0,0 +1,62 @@
+package writing
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// dataTag delimits untrusted text in prompts. The system instruction tells the model that
+// nothing inside these blocks is an instruction.
+const dataTag = "untrusted_data"
+
+var (
+	// ansiPattern matches terminal escape sequences (CSI and OSC).
+	ansiPattern = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+	// injectionPatterns match phrases that try to steer the model rather than describe content.
+	injectionPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override|bypass)\b[^.\n]{0,40}\b(instructions?|prompts?|rules?|context|guidelines)\b`),
+		// Problem statements say "each new task" or "the new instructions", so only a phrase
+		// addressed to the model or introducing a replacement block counts.
+		regexp.MustCompile(`(?i)\byour\s+(new|updated|real|actual)\s+(instructions?|task|system\s+prompt)\b|\b(new|updated|real|actual)\s+(instructions?|task|system\s+prompt)\s*:`),
+		regexp.MustCompile(`(?i)\byou\s+(are|must)\s+now\b`),
+		regexp.MustCompile(`(?i)\b(pretend|roleplay)\s+(to\s+be|as)\b`),
+		regexp.MustCompile(`(?im)^\s*(system|developer|assistant)\s*(prompt|message)?\s*:`),
+		regexp.MustCompile(`(?i)<\|?/?(im_start|im_end|system|endoftext)\|?>`),
+		regexp.MustCompile(`(?i)</?\s*` + dataTag + `\s*>|` + dataTag),
+	}
+)
+
+// sanitizeUntrusted prepares scraped text for a data block: escape sequences, control and
+// invisible formatting characters are dropped, instruction-like phrases are replaced and block
+// delimiters or code fences cannot be forged. Unless multiline is set, the text is flattened to one line.
+func sanitizeUntrusted(text string, multiline bool) string {
+	text = ansiPattern.ReplaceAllString(text, "")
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\t':
+			return r
+		case r == '\r':
+			return -1
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r):
+			// Cf covers zero-width spaces, bidi overrides and the BOM.
+			return -1
+		}
+		return r
+	}, text)
+
+	for _, pattern := range injectionPatterns {
+		text = pattern.ReplaceAllString(text, "[removed]")
+	}
+	text = strings.ReplaceAll(text, "```", "'''")
+
+	if !multiline {
+		return strings.Join(strings.Fields(text), " ")
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
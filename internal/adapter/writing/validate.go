@@ -0,0 +1,222 @@
+package writing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// minVietnameseRatio is the share of words that must carry Vietnamese diacritics. English tech
+// terms are expected, so the bar is well below what plain Vietnamese prose reaches.
+const minVietnameseRatio = 0.25
+
+var (
+	markdownLinkPattern = regexp.MustCompile(`\[[^\]]*\]\(([^)\s]*)\)`)
+	bareURLPattern      = regexp.MustCompile(`https?://[^\s)<>\]]+`)
+	mentionPattern      = regexp.MustCompile(`@(everyone|here)\b|<@[!&]?\d+>`)
+)
+
+var unsafeMarkers = []string{"discord.gg/", "discord.com/invite", "<script", "javascript:", "data:text/html"}
+
+// Validator checks structured insights before they are posted.
+type Validator struct {
+	allowedHosts []string
+}
+
+// NewValidator builds a Validator. Links must point at one of allowedHosts (subdomains included)
+// or at a page already listed in the prompt.
+func NewValidator(allowedHosts []string) *Validator {
+	hosts := make([]string, 0, len(allowedHosts))
+	for _, host := range allowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return &Validator{allowedHosts: hosts}
+}
+
+// ValidateInsight returns one readable problem per failed check; an empty result means the insight can be posted.
+func (v *Validator) ValidateInsight(insight model.Insight, data PromptData) []string {
+	sections := []struct {
+		key   string
+		value string
+		limit int
+	}{
+		{"analysis", insight.Analysis, model.AnalysisLimit},
+		{"grokking_concept", insight.Concept, model.ConceptLimit},
+		{"study_plan", insight.StudyPlan, model.StudyPlanLimit},
+		{"complexity", insight.Complexity, model.ComplexityLimit},
+	}
+
+	var problems []string
+	var all strings.Builder
+	for _, section := range sections {
+		if section.value == "" {
+			problems = append(problems, fmt.Sprintf("%s is missing", section.key))
+			continue
+		}
+		if length := utf8.RuneCountInString(section.value); length > section.limit {
+			problems = append(problems, fmt.Sprintf("%s is %d characters, keep it under %d", section.key, length, section.limit))
+		}
+		all.WriteString(section.value)
+		all.WriteString("\n")
+	}
+
+	text := all.String()
+	if !looksVietnamese(text) {
+		problems = append(problems, "write the prose in Vietnamese, keeping only tech terms in English")
+	}
+	problems = append(problems, v.checkLinks(text, data)...)
+	problems = append(problems, checkUnsafe(text)...)
+	return problems
+}
+
+func (v *Validator) checkLinks(text string, data PromptData) []string {
+	known := promptLinks(data)
+
+	var links []string
+	for _, match := range markdownLinkPattern.FindAllStringSubmatch(text, -1) {
+		links = append(links, match[1])
+	}
+	for _, match := range bareURLPattern.FindAllString(markdownLinkPattern.ReplaceAllString(text, ""), -1) {
+		links = append(links, strings.TrimRight(match, ".,;:!?"))
+	}
+
+	var problems []string
+	for _, link := range links {
+		parsed, err := url.Parse(link)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			problems = append(problems, fmt.Sprintf("link %q is not a valid http(s) URL", link))
+			continue
+		}
+		if known[strings.TrimSuffix(link, "/")] || v.hostAllowed(parsed.Hostname()) {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("link %q is not on the allowed list; only link the problems and articles given", link))
+	}
+	return problems
+}
+
+func (v *Validator) hostAllowed(host string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range v.allowedHosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func promptLinks(data PromptData) map[string]bool {
+	known := make(map[string]bool)
+	add := func(link string) {
+		if link != "" {
+			known[strings.TrimSuffix(link, "/")] = true
+		}
+	}
+	if data.Daily != nil {
+		add(data.Daily.Link)
+	}
+	for _, problem := range data.Random {
+		add(problem.Link)
+	}
+	for _, article := range data.Articles {
+		add(article.Link)
+	}
+	return known
+}
+
+func checkUnsafe(text string) []string {
+	var problems []string
+	if mentionPattern.MatchString(text) {
+		problems = append(problems, "remove @everyone, @here and user or role mentions")
+	}
+	lower := strings.ToLower(text)
+	for _, marker := range unsafeMarkers {
+		if strings.Contains(lower, marker) {
+			problems = append(problems, fmt.Sprintf("remove unsafe content %q", marker))
+		}
+	}
+	for _, r := range text {
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			problems = append(problems, "remove control characters")
+			break
+		}
+	}
+	return problems
+}
+
+// looksVietnamese reports whether enough words carry Vietnamese diacritics or the letter đ.
+func looksVietnamese(text string) bool {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return false
+	}
+	marked := 0
+	for _, word := range words {
+		for _, r := range word {
+			if isVietnameseLetter(r) {
+				marked++
+				break
+			}
+		}
+	}
+	return float64(marked)/float64(len(words)) >= minVietnameseRatio
+}
+
+func isVietnameseLetter(r rune) bool {
+	r = unicode.ToLower(r)
+	switch {
+	case r == 'đ', r == 'ă', r == 'ơ', r == 'ư':
+		return true
+	case r >= 0x00E0 && r <= 0x00FD: // à á â ã è é ê ì í ò ó ô õ ù ú ý
+		return true
+	case r == 0x0129, r == 0x0169: // ĩ ũ
+		return true
+	case r >= 0x1EA0 && r <= 0x1EF9: // Latin Extended Additional: ạ ả ấ ... ỹ
+		return true
+	}
+	return false
+}
+
+// composeValidated renders the digest prompt, parses and validates the answer and re-prompts once
+// with the problems found. A second failure is returned so callers fall back to the plain digest.
+func composeValidated(ctx context.Context, prompts *Prompts, validator *Validator, data PromptData, logger ports.Logger, generate func(ctx context.Context, prompt string) (string, error)) (model.Insight, error) {
+	var problems []string
+	for attempt := 0; attempt < 2; attempt++ {
+		data.Feedback = problems
+		prompt, err := prompts.Render(promptDigest, data)
+		if err != nil {
+			return model.Insight{}, err
+		}
+
+		text, err := generate(ctx, prompt)
+		if err != nil {
+			return model.Insight{}, err
+		}
+
+		insight, err := parseInsight(text)
+		if err != nil {
+			problems = []string{err.Error()}
+		} else if validator == nil {
+			return insight, nil
+		} else {
+			problems = validator.ValidateInsight(insight, data)
+		}
+		if len(problems) == 0 {
+			return insight, nil
+		}
+
+		if logger != nil {
+			logger.Error(ctx, "insight failed validation", "attempt", attempt+1, "problems", strings.Join(problems, "; "))
+		}
+	}
+	return model.Insight{}, fmt.Errorf("insight failed validation: %s", strings.Join(problems, "; "))
+}
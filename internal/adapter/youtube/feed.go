@@ -0,0 +1,180 @@
+package youtube
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+const feedEndpoint = "https://www.youtube.com/feeds/videos.xml"
+
+// FeedProvider matches videos from public YouTube channel and playlist Atom feeds.
+type FeedProvider struct {
+	httpClient  *http.Client
+	logger      ports.Logger
+	channelIDs  []string
+	playlistIDs []string
+}
+
+var _ ports.VideoProvider = (*FeedProvider)(nil)
+
+// NewFeedProvider builds a provider that reads the given channel and playlist feeds.
+func NewFeedProvider(channelIDs, playlistIDs []string, timeout time.Duration, logger ports.Logger) *FeedProvider {
+	return &FeedProvider{
+		httpClient:  &http.Client{Timeout: timeout},
+		logger:      logger,
+		channelIDs:  channelIDs,
+		playlistIDs: playlistIDs,
+	}
+}
+
+// FindVideos returns up to count videos whose title mentions the problem.
+func (f *FeedProvider) FindVideos(ctx context.Context, problem *model.Problem, count int) ([]model.Video, error) {
+	if problem == nil || count <= 0 {
+		return nil, nil
+	}
+
+	feeds := make([]string, 0, len(f.channelIDs)+len(f.playlistIDs))
+	for _, id := range f.channelIDs {
+		feeds = append(feeds, feedEndpoint+"?channel_id="+url.QueryEscape(id))
+	}
+	for _, id := range f.playlistIDs {
+		feeds = append(feeds, feedEndpoint+"?playlist_id="+url.QueryEscape(id))
+	}
+	if len(feeds) == 0 {
+		return nil, nil
+	}
+
+	matches := make([]model.Video, 0, count)
+	seen := make(map[string]struct{})
+	var lastErr error
+	failures := 0
+
+	for _, feedURL := range feeds {
+		videos, err := f.fetchFeed(ctx, feedURL)
+		if err != nil {
+			lastErr = err
+			failures++
+			if f.logger != nil {
+				f.logger.Error(ctx, "youtube feed failed", "feed", feedURL, "error", err)
+			}
+			continue
+		}
+
+		for _, video := range videos {
+			if _, exists := seen[video.Link]; exists {
+				continue
+			}
+			if !matchesProblem(video.Title, problem) {
+				continue
+			}
+			seen[video.Link] = struct{}{}
+			matches = append(matches, video)
+			if len(matches) >= count {
+				return matches, nil
+			}
+		}
+	}
+
+	if len(matches) == 0 && failures == len(feeds) {
+		return nil, lastErr
+	}
+
+	return matches, nil
+}
+
+func (f *FeedProvider) fetchFeed(ctx context.Context, feedURL string) ([]model.Video, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create youtube request: %w", err)
+	}
+	req.Header.Set("Accept", "application/atom+xml, application/xml")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch youtube feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("youtube feed status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var payload struct {
+		Title   string `xml:"title"`
+		Entries []struct {
+			Title string `xml:"title"`
+			Link  struct {
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+			Author struct {
+				Name string `xml:"name"`
+			} `xml:"author"`
+			Published string `xml:"published"`
+		} `xml:"entry"`
+	}
+
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 1024*1024)).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode youtube feed: %w", err)
+	}
+
+	videos := make([]model.Video, 0, len(payload.Entries))
+	for _, entry := range payload.Entries {
+		title := strings.TrimSpace(entry.Title)
+		link := strings.TrimSpace(entry.Link.Href)
+		if title == "" || link == "" {
+			continue
+		}
+		channel := strings.TrimSpace(entry.Author.Name)
+		if channel == "" {
+			channel = strings.TrimSpace(payload.Title)
+		}
+		published, _ := time.Parse(time.RFC3339, strings.TrimSpace(entry.Published))
+		videos = append(videos, model.Video{
+			Title:       title,
+			Link:        link,
+			Channel:     channel,
+			PublishedAt: published,
+		})
+	}
+
+	return videos, nil
+}
+
+// matchesProblem reports whether a video title refers to the problem by title, slug or number.
+func matchesProblem(videoTitle string, problem *model.Problem) bool {
+	normalized := " " + normalize(videoTitle) + " "
+
+	if title := normalize(problem.Title); title != "" && strings.Contains(normalized, " "+title+" ") {
+		return true
+	}
+	if slug := normalize(problem.Slug); slug != "" && strings.Contains(normalized, " "+slug+" ") {
+		return true
+	}
+	if problem.ID > 0 {
+		id := strconv.Itoa(problem.ID)
+		if strings.Contains(normalized, " leetcode "+id+" ") || strings.Contains(normalized, " lc "+id+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize lowercases text and reduces it to space separated alphanumeric words.
+func normalize(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
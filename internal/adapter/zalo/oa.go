@@ -0,0 +1,356 @@
+package zalo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"bot-viethoang/internal/adapter/filestore"
+	"bot-viethoang/internal/adapter/markdown"
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+const (
+	// DefaultAPIURL and DefaultOAuthURL are the public Zalo endpoints.
+	DefaultAPIURL   = "https://openapi.zalo.me"
+	DefaultOAuthURL = "https://oauth.zaloapp.com"
+
+	maxTextLength   = 2000
+	maxListElements = 5
+	maxElementTitle = 100
+	maxSubtitle     = 500
+	followerPage    = 50
+)
+
+// Zalo answers these codes when the access token is invalid or expired.
+var tokenErrors = map[int]bool{-216: true, -124: true}
+
+var linkPattern = regexp.MustCompile(`\[([^\]\n]+)\]\((https?://[^)\s]+)\)`)
+
+// Config holds the OA credentials, recipients and endpoints.
+type Config struct {
+	APIURL       string
+	OAuthURL     string
+	AppID        string
+	AppSecret    string
+	AccessToken  string
+	RefreshToken string
+	// UserIDs limits delivery to these followers; empty broadcasts to every follower.
+	UserIDs []string
+	Timeout time.Duration
+}
+
+// OA is a notifier that sends notifications to followers of a Zalo Official Account.
+type OA struct {
+	apiURL     string
+	userIDs    []string
+	tokens     *tokenSource
+	httpClient *http.Client
+	logger     ports.Logger
+}
+
+// NewOA creates a Zalo OA notifier. store keeps rotated tokens and may be nil.
+func NewOA(cfg Config, store *filestore.Store, logger ports.Logger) *OA {
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	oauthURL := cfg.OAuthURL
+	if oauthURL == "" {
+		oauthURL = DefaultOAuthURL
+	}
+	httpClient := &http.Client{Timeout: cfg.Timeout}
+	return &OA{
+		apiURL:     strings.TrimRight(apiURL, "/"),
+		userIDs:    cfg.UserIDs,
+		tokens:     newTokenSource(strings.TrimRight(oauthURL, "/"), cfg.AppID, cfg.AppSecret, cfg.AccessToken, cfg.RefreshToken, store, httpClient, logger),
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+// Send delivers the notification to every recipient. It fails only when no recipient received it;
+// partial failures, common for followers outside Zalo's messaging window, are logged.
+func (o *OA) Send(ctx context.Context, notification model.Notification) error {
+	recipients := o.userIDs
+	if len(recipients) == 0 {
+		followers, err := o.followers(ctx)
+		if err != nil {
+			return err
+		}
+		recipients = followers
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("zalo OA has no followers to notify")
+	}
+
+	messages := buildMessages(notification)
+
+	var errs []error
+	for _, userID := range recipients {
+		if err := o.sendUser(ctx, userID, messages); err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", userID, err))
+		}
+	}
+	if len(errs) == len(recipients) {
+		return errors.Join(errs...)
+	}
+
+	if o.logger != nil {
+		if len(errs) > 0 {
+			o.logger.Error(ctx, "zalo delivery failed for some followers", "failed", len(errs), "recipients", len(recipients), "error", errors.Join(errs...))
+		}
+		o.logger.Info(ctx, "notification sent to zalo", "recipients", len(recipients)-len(errs), "messages", len(messages))
+	}
+	return nil
+}
+
+func (o *OA) sendUser(ctx context.Context, userID string, messages []map[string]any) error {
+	for _, message := range messages {
+		payload := map[string]any{
+			"recipient": map[string]string{"user_id": userID},
+			"message":   message,
+		}
+		if _, err := o.call(ctx, http.MethodPost, "/v3.0/oa/message/cs", payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// followers pages through the OA's follower list.
+func (o *OA) followers(ctx context.Context) ([]string, error) {
+	var ids []string
+	for offset := 0; ; {
+		query, _ := json.Marshal(map[string]any{"offset": offset, "count": followerPage, "is_follower": true})
+		data, err := o.call(ctx, http.MethodGet, "/v3.0/oa/user/getlist?data="+url.QueryEscape(string(query)), nil)
+		if err != nil {
+			return nil, fmt.Errorf("list zalo followers: %w", err)
+		}
+
+		var page struct {
+			Total int `json:"total"`
+			Users []struct {
+				UserID string `json:"user_id"`
+			} `json:"users"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("decode zalo followers: %w", err)
+		}
+		for _, user := range page.Users {
+			ids = append(ids, user.UserID)
+		}
+		offset += len(page.Users)
+		if len(page.Users) == 0 || offset >= page.Total {
+			return ids, nil
+		}
+	}
+}
+
+type apiResponse struct {
+	Error   int             `json:"error"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// call performs an OA API request and returns its data. A rejected access token is refreshed and
+// the request retried once.
+func (o *OA) call(ctx context.Context, method, path string, payload any) (json.RawMessage, error) {
+	var body []byte
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("marshal zalo payload: %w", err)
+		}
+		body = encoded
+	}
+
+	force := false
+	for attempt := 0; ; attempt++ {
+		token, err := o.tokens.Token(ctx, force)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := o.do(ctx, method, path, body, token)
+		if err != nil {
+			return nil, err
+		}
+		if result.Error == 0 {
+			return result.Data, nil
+		}
+		if tokenErrors[result.Error] && attempt == 0 {
+			force = true
+			continue
+		}
+		return nil, fmt.Errorf("zalo api error %d: %s", result.Error, result.Message)
+	}
+}
+
+func (o *OA) do(ctx context.Context, method, path string, body []byte, token string) (apiResponse, error) {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, o.apiURL+path, reader)
+	if err != nil {
+		return apiResponse{}, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("access_token", token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return apiResponse{}, fmt.Errorf("perform request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 256*1024))
+	var result apiResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return apiResponse{}, fmt.Errorf("zalo api returned status %d", resp.StatusCode)
+	}
+	return result, nil
+}
+
+// buildMessages maps the notification onto Zalo messages: the title, description and plain
+// sections become text messages, and sections listing links (problems, articles, videos) become
+// list templates whose elements open the link.
+func buildMessages(notification model.Notification) []map[string]any {
+	var messages []map[string]any
+	var text []string
+	flush := func() {
+		for _, chunk := range splitText(strings.Join(text, "\n\n"), maxTextLength) {
+			messages = append(messages, map[string]any{"text": chunk})
+		}
+		text = nil
+	}
+
+	header := notification.Title
+	if notification.Description != "" {
+		header += "\n" + markdown.ToPlain(notification.Description)
+	}
+	text = append(text, header)
+
+	if poll := notification.Poll; poll != nil {
+		lines := []string{poll.Question}
+		for i, answer := range poll.Answers {
+			lines = append(lines, fmt.Sprintf("%c. %s", 'A'+i, answer))
+		}
+		text = append(text, strings.Join(lines, "\n"))
+	}
+
+	for _, field := range notification.Fields {
+		elements := listElements(field)
+		if len(elements) == 0 {
+			text = append(text, strings.TrimSpace(markdown.ToPlain(field.Name)+"\n"+markdown.ToPlain(field.Value)))
+			continue
+		}
+		flush()
+		messages = append(messages, map[string]any{
+			"attachment": map[string]any{
+				"type": "template",
+				"payload": map[string]any{
+					"template_type": "list",
+					"elements":      elements,
+				},
+			},
+		})
+	}
+	flush()
+	return messages
+}
+
+// listElements turns a section that lists links into list elements. A line is an item when only
+// numbering or markers precede its link; the section name, the rest of that line and the lines
+// below it form the subtitle. Prose that merely contains a link yields no elements.
+func listElements(field model.NotificationField) []map[string]any {
+	type item struct {
+		title, url string
+		details    []string
+	}
+	var items []*item
+	for _, line := range strings.Split(field.Value, "\n") {
+		rest := plainLine(line)
+		if rest == "" {
+			continue
+		}
+		loc := linkPattern.FindStringSubmatchIndex(line)
+		if loc != nil && !hasLetter(markdown.ToPlain(line[:loc[0]])) {
+			current := &item{title: line[loc[2]:loc[3]], url: line[loc[4]:loc[5]], details: []string{markdown.ToPlain(field.Name)}}
+			if rest := plainLine(linkPattern.ReplaceAllString(line, "")); hasLetter(rest) {
+				current.details = append(current.details, rest)
+			}
+			items = append(items, current)
+			continue
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		last := items[len(items)-1]
+		last.details = append(last.details, rest)
+	}
+
+	elements := make([]map[string]any, 0, min(len(items), maxListElements))
+	for _, it := range items[:min(len(items), maxListElements)] {
+		elements = append(elements, map[string]any{
+			"title":    truncate(markdown.ToPlain(it.title), maxElementTitle),
+			"subtitle": truncate(strings.Join(it.details, " · "), maxSubtitle),
+			"default_action": map[string]string{
+				"type": "oa.open.url",
+				"url":  it.url,
+			},
+		})
+	}
+	return elements
+}
+
+func plainLine(line string) string {
+	line = strings.TrimPrefix(strings.TrimSpace(line), ">")
+	return strings.Join(strings.Fields(markdown.ToPlain(line)), " ")
+}
+
+func hasLetter(text string) bool {
+	return strings.IndexFunc(text, unicode.IsLetter) >= 0
+}
+
+// splitText cuts text into messages of at most limit characters, preferring line breaks.
+func splitText(text string, limit int) []string {
+	var chunks []string
+	runes := []rune(strings.TrimSpace(text))
+	for len(runes) > limit {
+		cut := limit
+		for i := limit; i > limit/2; i-- {
+			if runes[i] == '\n' {
+				cut = i
+				break
+			}
+		}
+		chunks = append(chunks, strings.TrimSpace(string(runes[:cut])))
+		runes = []rune(strings.TrimSpace(string(runes[cut:])))
+	}
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+	return chunks
+}
+
+func truncate(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
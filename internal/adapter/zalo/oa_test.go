@@ -0,0 +1,147 @@
+package zalo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bot-viethoang/internal/adapter/filestore"
+	"bot-viethoang/internal/adapter/logging"
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// stubZalo serves the OA message API and the OAuth refresh endpoint. Only validToken is accepted;
+// a refresh hands out the next access and refresh token pair.
+type stubZalo struct {
+	mu           sync.Mutex
+	validToken   string
+	refreshCalls []string
+	usedTokens   []string
+	nextAccess   string
+	nextRefresh  string
+}
+
+func (s *stubZalo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/v4/oa/access_token":
+		_ = r.ParseForm()
+		s.refreshCalls = append(s.refreshCalls, r.PostForm.Get("refresh_token"))
+		s.validToken = s.nextAccess
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token":  s.nextAccess,
+			"refresh_token": s.nextRefresh,
+			"expires_in":    "90000",
+		})
+	case "/v3.0/oa/message/cs":
+		token := r.Header.Get("access_token")
+		s.usedTokens = append(s.usedTokens, token)
+		if token != s.validToken {
+			_, _ = w.Write([]byte(`{"error":-216,"message":"Access token is invalid"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"error":0,"message":"Success","data":{}}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestOA(t *testing.T, stub *stubZalo, store *filestore.Store, logger ports.Logger, access, refresh string) *OA {
+	t.Helper()
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return NewOA(Config{
+		APIURL:       server.URL,
+		OAuthURL:     server.URL,
+		AppID:        "app-id",
+		AppSecret:    "app-secret",
+		AccessToken:  access,
+		RefreshToken: refresh,
+		UserIDs:      []string{"user-1"},
+		Timeout:      5 * time.Second,
+	}, store, logger)
+}
+
+func newTestStore(t *testing.T) *filestore.Store {
+	t.Helper()
+	store, err := filestore.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("filestore.New: %v", err)
+	}
+	return store
+}
+
+func TestSendRefreshesRejectedTokenAndMasksIt(t *testing.T) {
+	stub := &stubZalo{validToken: "", nextAccess: "rotated-access-token-1", nextRefresh: "rotated-refresh-token-1"}
+	store := newTestStore(t)
+	var logs bytes.Buffer
+	logger := logging.New(slog.New(slog.NewTextHandler(&logs, nil)))
+	oa := newTestOA(t, stub, store, logger, "expired-access-token", "env-refresh-token")
+
+	if err := oa.Send(context.Background(), model.Notification{Title: "Daily"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(stub.refreshCalls) != 1 || stub.refreshCalls[0] != "env-refresh-token" {
+		t.Fatalf("refresh calls = %q", stub.refreshCalls)
+	}
+	if got := strings.Join(stub.usedTokens, ","); got != "expired-access-token,rotated-access-token-1" {
+		t.Errorf("tokens used = %s", got)
+	}
+
+	var saved tokenState
+	if ok, err := store.Load(tokenKey, &saved); !ok || err != nil {
+		t.Fatalf("saved token missing: %v", err)
+	}
+	if saved.AccessToken != "rotated-access-token-1" || saved.RefreshToken != "rotated-refresh-token-1" || saved.ExpiresAt.IsZero() {
+		t.Errorf("saved state = %+v", saved)
+	}
+
+	logger.Info(context.Background(), "debug", "token", saved.AccessToken, "url", "https://x.test/?refresh="+saved.RefreshToken)
+	for _, secret := range []string{saved.AccessToken, saved.RefreshToken, "expired-access-token", "env-refresh-token"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("logs contain %q:\n%s", secret, logs.String())
+		}
+	}
+}
+
+func TestSavedTokensLastUntilEnvTokensChange(t *testing.T) {
+	store := newTestStore(t)
+	first := &stubZalo{nextAccess: "rotated-access-token-1", nextRefresh: "rotated-refresh-token-1"}
+	oa := newTestOA(t, first, store, nil, "env-access-token-a", "env-refresh-token-a")
+	if err := oa.Send(context.Background(), model.Notification{Title: "Daily"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	// A restart with the same env tokens resumes from the rotated pair.
+	restarted := &stubZalo{validToken: "rotated-access-token-1"}
+	oa = newTestOA(t, restarted, store, nil, "env-access-token-a", "env-refresh-token-a")
+	if err := oa.Send(context.Background(), model.Notification{Title: "Daily"}); err != nil {
+		t.Fatalf("Send after restart: %v", err)
+	}
+	if got := strings.Join(restarted.usedTokens, ","); got != "rotated-access-token-1" {
+		t.Errorf("after restart used %s, want the saved token", got)
+	}
+
+	// New env tokens from the operator replace the saved pair.
+	replaced := &stubZalo{validToken: "env-access-token-b"}
+	oa = newTestOA(t, replaced, store, nil, "env-access-token-b", "env-refresh-token-b")
+	if err := oa.Send(context.Background(), model.Notification{Title: "Daily"}); err != nil {
+		t.Fatalf("Send with new env tokens: %v", err)
+	}
+	if got := strings.Join(replaced.usedTokens, ","); got != "env-access-token-b" {
+		t.Errorf("with new env tokens used %s", got)
+	}
+	if len(replaced.refreshCalls) != 0 {
+		t.Errorf("refreshed with %q, want no refresh", replaced.refreshCalls)
+	}
+}
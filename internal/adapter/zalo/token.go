@@ -0,0 +1,188 @@
+package zalo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"bot-viethoang/internal/adapter/filestore"
+	"bot-viethoang/internal/domain/ports"
+)
+
+const (
+	tokenKey = "zalo-token"
+	// refreshMargin renews the access token shortly before Zalo expires it.
+	refreshMargin = 5 * time.Minute
+)
+
+type tokenState struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	// Seed fingerprints the env tokens the state grew from, so new env tokens replace it.
+	Seed string `json:"seed"`
+}
+
+// secretMasker is implemented by loggers that can mask values learned at runtime.
+type secretMasker interface {
+	AddSecrets(secrets ...string)
+}
+
+// tokenSource hands out OA access tokens. Zalo refresh tokens are single use, so every rotation is
+// persisted and survives restarts.
+type tokenSource struct {
+	mu         sync.Mutex
+	oauthURL   string
+	appID      string
+	appSecret  string
+	state      tokenState
+	store      *filestore.Store
+	httpClient *http.Client
+	logger     ports.Logger
+}
+
+func newTokenSource(oauthURL, appID, appSecret, accessToken, refreshToken string, store *filestore.Store, httpClient *http.Client, logger ports.Logger) *tokenSource {
+	seed := tokenSeed(accessToken, refreshToken)
+	source := &tokenSource{
+		oauthURL:   oauthURL,
+		appID:      appID,
+		appSecret:  appSecret,
+		state:      tokenState{AccessToken: accessToken, RefreshToken: refreshToken, Seed: seed},
+		store:      store,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+	if store != nil {
+		var saved tokenState
+		ok, err := store.Load(tokenKey, &saved)
+		switch {
+		case err != nil:
+			if logger != nil {
+				logger.Error(context.Background(), "failed to load zalo token", "error", err)
+			}
+		case !ok || saved.RefreshToken == "":
+		case saved.Seed != seed:
+			// The env tokens were replaced since the saved pair was issued; the operator's new
+			// tokens win over the rotated ones.
+			if logger != nil {
+				logger.Info(context.Background(), "zalo tokens in env changed, ignoring saved token")
+			}
+		default:
+			source.state = saved
+		}
+	}
+	source.maskTokens()
+	return source
+}
+
+// tokenSeed fingerprints the env tokens without storing them.
+func tokenSeed(accessToken, refreshToken string) string {
+	sum := sha256.Sum256([]byte(accessToken + "\x00" + refreshToken))
+	return hex.EncodeToString(sum[:8])
+}
+
+// maskTokens keeps the current tokens, which change on every refresh, out of the logs.
+func (t *tokenSource) maskTokens() {
+	if masker, ok := t.logger.(secretMasker); ok {
+		masker.AddSecrets(t.state.AccessToken, t.state.RefreshToken)
+	}
+}
+
+// Token returns a usable access token, refreshing it when missing, about to expire or when force
+// is set after Zalo rejected the current one.
+func (t *tokenSource) Token(ctx context.Context, force bool) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	expiring := !t.state.ExpiresAt.IsZero() && time.Now().Add(refreshMargin).After(t.state.ExpiresAt)
+	if t.state.AccessToken != "" && !expiring && !force {
+		return t.state.AccessToken, nil
+	}
+	if t.state.RefreshToken == "" || t.appID == "" || t.appSecret == "" {
+		if t.state.AccessToken != "" && !force {
+			return t.state.AccessToken, nil
+		}
+		return "", fmt.Errorf("zalo access token expired and no refresh token, app ID or secret is configured")
+	}
+
+	if err := t.refresh(ctx); err != nil {
+		return "", err
+	}
+	return t.state.AccessToken, nil
+}
+
+type refreshResponse struct {
+	AccessToken      string          `json:"access_token"`
+	RefreshToken     string          `json:"refresh_token"`
+	ExpiresIn        json.RawMessage `json:"expires_in"`
+	Error            int             `json:"error"`
+	ErrorName        string          `json:"error_name"`
+	ErrorDescription string          `json:"error_description"`
+}
+
+func (t *tokenSource) refresh(ctx context.Context) error {
+	form := url.Values{
+		"app_id":        {t.appID},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {t.state.RefreshToken},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.oauthURL+"/v4/oa/access_token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("secret_key", t.appSecret)
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("refresh zalo token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var result refreshResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("decode zalo token response (status %d): %w", resp.StatusCode, err)
+	}
+	if result.AccessToken == "" {
+		return fmt.Errorf("refresh zalo token: error %d %s: %s", result.Error, result.ErrorName, result.ErrorDescription)
+	}
+
+	t.state.AccessToken = result.AccessToken
+	if result.RefreshToken != "" {
+		t.state.RefreshToken = result.RefreshToken
+	}
+	t.state.ExpiresAt = time.Time{}
+	if seconds := parseSeconds(result.ExpiresIn); seconds > 0 {
+		t.state.ExpiresAt = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	t.maskTokens()
+
+	if t.store != nil {
+		if err := t.store.Save(tokenKey, t.state); err != nil && t.logger != nil {
+			t.logger.Error(ctx, "failed to persist zalo token", "error", err)
+		}
+	}
+	if t.logger != nil {
+		t.logger.Info(ctx, "zalo access token refreshed", "expires_at", t.state.ExpiresAt)
+	}
+	return nil
+}
+
+// parseSeconds reads expires_in, which Zalo sends as a quoted number.
+func parseSeconds(raw json.RawMessage) int {
+	seconds, err := strconv.Atoi(strings.Trim(string(raw), `"`))
+	if err != nil {
+		return 0
+	}
+	return seconds
+}
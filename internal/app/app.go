@@ -2,6 +2,8 @@ package app
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"time"
 
 	"github.com/robfig/cron/v3"
@@ -10,36 +12,72 @@ import (
 	"bot-viethoang/internal/usecase"
 )
 
+// Schedules holds the cron expressions for every scheduled job. Empty hint entries disable them.
+type Schedules struct {
+	Digest      string
+	Hint1       string
+	Hint2       string
+	Approach    string
+	QuizReveal  string
+	WeeklyRecap string
+}
+
+// approachFirstHint is the first hint posted with the approach; the hints before it have their
+// own jobs.
+const approachFirstHint = 3
+
+// Server is an auxiliary HTTP server, such as the metrics endpoint, run next to the scheduler.
+type Server interface {
+	ListenAndServe() error
+	Shutdown(ctx context.Context) error
+}
+
 // App manages the lifecycle of the daily digest scheduler.
 type App struct {
-	cron     *cron.Cron
-	usecase  *usecase.DailyDigest
-	logger   ports.Logger
-	schedule string
+	cron      *cron.Cron
+	usecase   *usecase.DailyDigest
+	hints     *usecase.HintRelease
+	quiz      *usecase.QuizReveal
+	recap     *usecase.WeeklyRecap
+	metrics   Server
+	logger    ports.Logger
+	schedules Schedules
 }
 
-// New constructs an App instance.
-func New(digest *usecase.DailyDigest, logger ports.Logger, schedule string) *App {
+// New constructs an App instance. hints, quiz and metrics may be nil when those features are disabled.
+func New(digest *usecase.DailyDigest, hints *usecase.HintRelease, quiz *usecase.QuizReveal, recap *usecase.WeeklyRecap, metrics Server, logger ports.Logger, schedules Schedules) *App {
 	return &App{
-		cron:     cron.New(),
-		usecase:  digest,
-		logger:   logger,
-		schedule: schedule,
+		cron:      cron.New(),
+		usecase:   digest,
+		hints:     hints,
+		quiz:      quiz,
+		recap:     recap,
+		metrics:   metrics,
+		logger:    logger,
+		schedules: schedules,
 	}
 }
 
 // Run executes the use case once immediately and then according to the cron schedule.
 func (a *App) Run(ctx context.Context) error {
-	if err := a.scheduleJob(); err != nil {
+	if err := a.scheduleJobs(); err != nil {
 		return err
 	}
 
+	if a.metrics != nil {
+		go func() {
+			if err := a.metrics.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				a.logger.Error(ctx, "metrics server failed", "error", err)
+			}
+		}()
+	}
+
 	a.logger.Info(ctx, "running first digest immediately")
 	if err := a.usecase.Run(ctx); err != nil {
 		a.logger.Error(ctx, "initial digest run failed", "error", err)
 	}
 
-	a.logger.Info(ctx, "starting scheduler", "cron", a.schedule)
+	a.logger.Info(ctx, "starting scheduler", "cron", a.schedules.Digest)
 	a.cron.Start()
 
 	<-ctx.Done()
@@ -48,16 +86,67 @@ func (a *App) Run(ctx context.Context) error {
 	case <-stopCtx.Done():
 	case <-time.After(5 * time.Second):
 	}
+	if a.metrics != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.metrics.Shutdown(shutdownCtx); err != nil {
+			a.logger.Error(shutdownCtx, "metrics server shutdown failed", "error", err)
+		}
+	}
 	a.logger.Info(context.Background(), "scheduler stopped")
 	return nil
 }
 
-func (a *App) scheduleJob() error {
-	_, err := a.cron.AddFunc(a.schedule, func() {
+func (a *App) scheduleJobs() error {
+	if err := a.scheduleJob(a.schedules.Digest, "scheduled digest run failed", a.usecase.Run); err != nil {
+		return err
+	}
+
+	if a.quiz != nil && a.schedules.QuizReveal != "" {
+		if err := a.scheduleJob(a.schedules.QuizReveal, "quiz reveal failed", a.quiz.Reveal); err != nil {
+			return err
+		}
+		a.logger.Info(context.Background(), "quiz reveal job scheduled", "cron", a.schedules.QuizReveal)
+	}
+
+	if a.recap != nil && a.schedules.WeeklyRecap != "" {
+		if err := a.scheduleJob(a.schedules.WeeklyRecap, "weekly recap failed", a.recap.Run); err != nil {
+			return err
+		}
+		a.logger.Info(context.Background(), "weekly recap job scheduled", "cron", a.schedules.WeeklyRecap)
+	}
+
+	if a.hints == nil {
+		return nil
+	}
+
+	jobs := []struct {
+		spec string
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{a.schedules.Hint1, "hint 1 release failed", func(ctx context.Context) error { return a.hints.ReleaseHint(ctx, 1) }},
+		{a.schedules.Hint2, "hint 2 release failed", func(ctx context.Context) error { return a.hints.ReleaseHint(ctx, 2) }},
+		{a.schedules.Approach, "approach release failed", func(ctx context.Context) error { return a.hints.ReleaseApproach(ctx, approachFirstHint) }},
+	}
+	for _, job := range jobs {
+		if job.spec == "" {
+			continue
+		}
+		if err := a.scheduleJob(job.spec, job.name, job.run); err != nil {
+			return err
+		}
+		a.logger.Info(context.Background(), "hint job scheduled", "cron", job.spec)
+	}
+	return nil
+}
+
+func (a *App) scheduleJob(spec, failure string, run func(ctx context.Context) error) error {
+	_, err := a.cron.AddFunc(spec, func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 		defer cancel()
-		if err := a.usecase.Run(ctx); err != nil {
-			a.logger.Error(ctx, "scheduled digest run failed", "error", err)
+		if err := run(ctx); err != nil {
+			a.logger.Error(ctx, failure, "error", err)
 		}
 	})
 	if err != nil {
@@ -4,20 +4,91 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// Flags holds command-line overrides that are not read from the environment.
+type Flags struct {
+	// Regenerate ignores cached writer output from earlier runs.
+	Regenerate bool
+}
+
 // Config contains runtime configuration values.
 type Config struct {
 	DiscordWebhookURL  string
 	DiscordBotToken    string
+	SlackWebhookURL    string
+	TelegramBotToken   string
+	TelegramChatIDs    []string
+	TelegramBaseURL    string
+	SMTPHost           string
+	SMTPPort           int
+	SMTPUsername       string
+	SMTPPassword       string
+	SMTPFrom           string
+	SMTPTLS            string
+	EmailTo            []string
+	ZaloAppID          string
+	ZaloAppSecret      string
+	ZaloAccessToken    string
+	ZaloRefreshToken   string
+	ZaloUserIDs        []string
+	ZaloAPIURL         string
+	ZaloOAuthURL       string
 	ScheduleCron       string
 	RandomProblemCount int
 	ArticleCount       int
 	RequestTimeout     time.Duration
 	GeminiAPIKey       string
+	GeminiAPIKeys      []string
+	GeminiKeyCooldown  time.Duration
 	GeminiModel        string
+	GeminiFallbacks    []string
+	WriterFallback     string
+	WriterBackoff      time.Duration
 	GeminiTopicLimit   int
+	GeminiArticles     bool
+	WriterBackend      string
+	OpenAIBaseURL      string
+	OpenAIAPIKey       string
+	OpenAIModel        string
+	PromptDir          string
+	PromptProfile      string
+	NotifierProfiles   map[string]string
+	KnowledgeFile      string
+	TeamName           string
+	DataDir            string
+	InsightCacheTTL    time.Duration
+	HintLadder         bool
+	Hint1Cron          string
+	Hint2Cron          string
+	ApproachCron       string
+	YouTubeChannelIDs  []string
+	YouTubePlaylistIDs []string
+	VideoCount         int
+	VibloTags          []string
+	RequireVietnamese  bool
+	SummarizeArticles  bool
+	ArticleMaxBytes    int
+	ArticleMaxChars    int
+	BlockedKeywords    []string
+	BlockedAuthors     []string
+	MinTitleLength     int
+	MaxTitleLength     int
+	CheckPaywall       bool
+	DailyTokenBudget   int
+	MonthlyTokenBudget int
+	MetricsAddr        string
+	ValidateOutput     bool
+	LinkAllowlist      []string
+	QuizEnabled        bool
+	QuizRevealCron     string
+	WeeklyRecap        bool
+	WeeklyRecapCron    string
+	Notifiers          []string
+	NotifyPolicy       string
+	NotifyRequired     []string
 }
 
 const (
@@ -27,9 +98,37 @@ const (
 	defaultTimeout          = 30 * time.Second
 	defaultWebhookURL       = ""
 	defaultBotToken         = ""
+	defaultTelegramBaseURL  = "https://api.telegram.org"
+	defaultSMTPPort         = 587
+	defaultSMTPTLS          = "starttls"
+	defaultZaloAPIURL       = "https://openapi.zalo.me"
+	defaultZaloOAuthURL     = "https://oauth.zaloapp.com"
 	defaultGeminiAPIKey     = ""
 	defaultGeminiModel      = "gemini-2.5-flash"
+	defaultGeminiFallbacks  = "gemini-2.5-flash-lite"
+	defaultWriterBackoff    = 2 * time.Second
+	defaultGeminiCooldown   = time.Minute
 	defaultGeminiTopicLimit = 3
+	defaultWriterBackend    = "gemini"
+	defaultOpenAIBaseURL    = "https://api.openai.com/v1"
+	defaultOpenAIModel      = "gpt-4o-mini"
+	defaultPromptProfile    = "default"
+	defaultDataDir          = "data"
+	defaultInsightCacheTTL  = 36 * time.Hour
+	defaultHint1Cron        = "0 12 * * *"
+	defaultHint2Cron        = "0 15 * * *"
+	defaultApproachCron     = "0 19 * * *"
+	defaultQuizRevealCron   = "0 17 * * *"
+	defaultWeeklyRecapCron  = "0 20 * * 0" // Sunday 20:00
+	defaultNotifiers        = "discord"
+	defaultNotifyPolicy     = "any"
+	defaultVideoCount       = 1
+	defaultArticleMaxBytes  = 1024 * 1024
+	defaultArticleMaxChars  = 6000
+	defaultBlockedKeywords  = "crypto,cryptocurrency,bitcoin,blockchain,nft,web3,forex,airdrop,casino,betting"
+	defaultMinTitleLength   = 5
+	defaultMaxTitleLength   = 150
+	defaultLinkAllowlist    = "leetcode.com,youtube.com,youtu.be,wikipedia.org,github.com,go.dev,medium.com,dev.to,viblo.asia,geeksforgeeks.org"
 )
 
 // Load builds a Config from environment variables with sane defaults.
@@ -37,17 +136,165 @@ func Load() (*Config, error) {
 	cfg := &Config{
 		DiscordWebhookURL:  getenvDefault("DISCORD_WEBHOOK_URL", defaultWebhookURL),
 		DiscordBotToken:    getenvDefault("DISCORD_BOT_TOKEN", defaultBotToken),
+		SlackWebhookURL:    getenvDefault("SLACK_WEBHOOK_URL", ""),
+		TelegramBotToken:   getenvDefault("TELEGRAM_BOT_TOKEN", ""),
+		TelegramChatIDs:    parseListDefault("TELEGRAM_CHAT_IDS", ""),
+		TelegramBaseURL:    getenvDefault("TELEGRAM_API_BASE_URL", defaultTelegramBaseURL),
+		SMTPHost:           getenvDefault("SMTP_HOST", ""),
+		SMTPPort:           parseIntDefault("SMTP_PORT", defaultSMTPPort),
+		SMTPUsername:       getenvDefault("SMTP_USERNAME", ""),
+		SMTPPassword:       getenvDefault("SMTP_PASSWORD", ""),
+		SMTPFrom:           getenvDefault("SMTP_FROM", ""),
+		SMTPTLS:            strings.ToLower(getenvDefault("SMTP_TLS", defaultSMTPTLS)),
+		EmailTo:            parseListDefault("EMAIL_TO", ""),
+		ZaloAppID:          getenvDefault("ZALO_APP_ID", ""),
+		ZaloAppSecret:      getenvDefault("ZALO_APP_SECRET", ""),
+		ZaloAccessToken:    getenvDefault("ZALO_ACCESS_TOKEN", ""),
+		ZaloRefreshToken:   getenvDefault("ZALO_REFRESH_TOKEN", ""),
+		ZaloUserIDs:        parseListDefault("ZALO_USER_IDS", ""),
+		ZaloAPIURL:         getenvDefault("ZALO_API_BASE_URL", defaultZaloAPIURL),
+		ZaloOAuthURL:       getenvDefault("ZALO_OAUTH_BASE_URL", defaultZaloOAuthURL),
 		ScheduleCron:       getenvDefault("SCHEDULE_CRON", defaultCron),
 		RandomProblemCount: parseIntDefault("RANDOM_PROBLEM_COUNT", defaultRandomCount),
 		ArticleCount:       parseIntDefault("ARTICLE_COUNT", defaultArticleCount),
 		RequestTimeout:     parseDurationDefault("REQUEST_TIMEOUT", defaultTimeout),
 		GeminiAPIKey:       getenvDefault("GEMINI_API_KEY", defaultGeminiAPIKey),
+		GeminiAPIKeys:      parseListDefault("GEMINI_API_KEYS", ""),
+		GeminiKeyCooldown:  parseDurationDefault("GEMINI_KEY_COOLDOWN", defaultGeminiCooldown),
 		GeminiModel:        getenvDefault("GEMINI_MODEL", defaultGeminiModel),
+		GeminiFallbacks:    parseListDefault("GEMINI_FALLBACK_MODELS", defaultGeminiFallbacks),
+		WriterFallback:     strings.ToLower(getenvDefault("WRITER_FALLBACK_BACKEND", "")),
+		WriterBackoff:      parseDurationDefault("WRITER_BACKOFF", defaultWriterBackoff),
 		GeminiTopicLimit:   parseIntDefault("GEMINI_TOPIC_LIMIT", defaultGeminiTopicLimit),
+		GeminiArticles:     parseBoolDefault("GEMINI_ARTICLE_SUGGESTIONS", false),
+		WriterBackend:      strings.ToLower(getenvDefault("WRITER_BACKEND", defaultWriterBackend)),
+		OpenAIBaseURL:      getenvDefault("OPENAI_BASE_URL", defaultOpenAIBaseURL),
+		OpenAIAPIKey:       getenvDefault("OPENAI_API_KEY", ""),
+		OpenAIModel:        getenvDefault("OPENAI_MODEL", defaultOpenAIModel),
+		PromptDir:          getenvDefault("PROMPT_DIR", ""),
+		PromptProfile:      getenvDefault("PROMPT_PROFILE", defaultPromptProfile),
+		NotifierProfiles:   make(map[string]string),
+		KnowledgeFile:      getenvDefault("KNOWLEDGE_FILE", ""),
+		TeamName:           getenvDefault("TEAM_NAME", ""),
+		DataDir:            getenvDefault("DATA_DIR", defaultDataDir),
+		InsightCacheTTL:    parseDurationDefault("INSIGHT_CACHE_TTL", defaultInsightCacheTTL),
+		HintLadder:         parseBoolDefault("HINT_LADDER_ENABLED", false),
+		Hint1Cron:          getenvDefault("HINT1_CRON", defaultHint1Cron),
+		Hint2Cron:          getenvDefault("HINT2_CRON", defaultHint2Cron),
+		ApproachCron:       getenvDefault("APPROACH_CRON", defaultApproachCron),
+		YouTubeChannelIDs:  parseListDefault("YOUTUBE_CHANNEL_IDS", ""),
+		YouTubePlaylistIDs: parseListDefault("YOUTUBE_PLAYLIST_IDS", ""),
+		VideoCount:         parseIntDefault("VIDEO_COUNT", defaultVideoCount),
+		VibloTags:          parseListDefault("VIBLO_TAGS", ""),
+		RequireVietnamese:  parseBoolDefault("REQUIRE_VIETNAMESE_ARTICLE", false),
+		SummarizeArticles:  parseBoolDefault("SUMMARIZE_ARTICLES", false),
+		ArticleMaxBytes:    parseIntDefault("ARTICLE_MAX_BYTES", defaultArticleMaxBytes),
+		ArticleMaxChars:    parseIntDefault("ARTICLE_MAX_CHARS", defaultArticleMaxChars),
+		BlockedKeywords:    parseListDefault("ARTICLE_BLOCKED_KEYWORDS", defaultBlockedKeywords),
+		BlockedAuthors:     parseListDefault("ARTICLE_BLOCKED_AUTHORS", ""),
+		MinTitleLength:     parseIntDefault("ARTICLE_MIN_TITLE_LENGTH", defaultMinTitleLength),
+		MaxTitleLength:     parseIntDefault("ARTICLE_MAX_TITLE_LENGTH", defaultMaxTitleLength),
+		CheckPaywall:       parseBoolDefault("MEDIUM_CHECK_PAYWALL", true),
+		DailyTokenBudget:   parseIntDefault("LLM_DAILY_TOKEN_BUDGET", 0),
+		MonthlyTokenBudget: parseIntDefault("LLM_MONTHLY_TOKEN_BUDGET", 0),
+		MetricsAddr:        getenvDefault("METRICS_ADDR", ""),
+		ValidateOutput:     parseBoolDefault("VALIDATE_LLM_OUTPUT", true),
+		LinkAllowlist:      parseListDefault("LINK_ALLOWLIST", defaultLinkAllowlist),
+		QuizEnabled:        parseBoolDefault("QUIZ_ENABLED", false),
+		QuizRevealCron:     getenvDefault("QUIZ_REVEAL_CRON", defaultQuizRevealCron),
+		WeeklyRecap:        parseBoolDefault("WEEKLY_RECAP_ENABLED", false),
+		WeeklyRecapCron:    getenvDefault("WEEKLY_RECAP_CRON", defaultWeeklyRecapCron),
+		Notifiers:          parseListDefault("NOTIFIERS", defaultNotifiers),
+		NotifyPolicy:       strings.ToLower(getenvDefault("NOTIFY_POLICY", defaultNotifyPolicy)),
+		NotifyRequired:     parseListDefault("NOTIFY_REQUIRED", ""),
+	}
+
+	if len(cfg.Notifiers) == 0 {
+		return nil, fmt.Errorf("NOTIFIERS must list at least one destination")
+	}
+	listed := make(map[string]bool, len(cfg.Notifiers))
+	for i, name := range cfg.Notifiers {
+		cfg.Notifiers[i] = strings.ToLower(name)
+		if listed[cfg.Notifiers[i]] {
+			return nil, fmt.Errorf("NOTIFIERS lists %q more than once", name)
+		}
+		listed[cfg.Notifiers[i]] = true
+		switch cfg.Notifiers[i] {
+		case "discord":
+			if cfg.DiscordWebhookURL == "" {
+				return nil, fmt.Errorf("DISCORD_WEBHOOK_URL is required")
+			}
+		case "slack":
+			if cfg.SlackWebhookURL == "" {
+				return nil, fmt.Errorf("SLACK_WEBHOOK_URL is required when NOTIFIERS lists slack")
+			}
+		case "telegram":
+			if cfg.TelegramBotToken == "" || len(cfg.TelegramChatIDs) == 0 {
+				return nil, fmt.Errorf("TELEGRAM_BOT_TOKEN and TELEGRAM_CHAT_IDS are required when NOTIFIERS lists telegram")
+			}
+		case "email":
+			if cfg.SMTPHost == "" || cfg.SMTPFrom == "" || len(cfg.EmailTo) == 0 {
+				return nil, fmt.Errorf("SMTP_HOST, SMTP_FROM and EMAIL_TO are required when NOTIFIERS lists email")
+			}
+			switch cfg.SMTPTLS {
+			case "starttls", "tls", "none":
+			default:
+				return nil, fmt.Errorf("SMTP_TLS must be starttls, tls or none, got %q", cfg.SMTPTLS)
+			}
+		case "zalo":
+			if cfg.ZaloAccessToken == "" && cfg.ZaloRefreshToken == "" {
+				return nil, fmt.Errorf("ZALO_ACCESS_TOKEN or ZALO_REFRESH_TOKEN is required when NOTIFIERS lists zalo")
+			}
+			if cfg.ZaloRefreshToken != "" && (cfg.ZaloAppID == "" || cfg.ZaloAppSecret == "") {
+				return nil, fmt.Errorf("ZALO_APP_ID and ZALO_APP_SECRET are required to refresh the Zalo access token")
+			}
+		default:
+			return nil, fmt.Errorf("NOTIFIERS must only list discord, slack, telegram, email or zalo, got %q", name)
+		}
+	}
+
+	for _, entry := range parseListDefault("NOTIFIER_PROMPT_PROFILES", "") {
+		name, profile, ok := strings.Cut(entry, ":")
+		name, profile = strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(profile)
+		if !ok || name == "" || profile == "" {
+			return nil, fmt.Errorf("NOTIFIER_PROMPT_PROFILES entries must look like notifier:profile, got %q", entry)
+		}
+		if !listed[name] {
+			return nil, fmt.Errorf("NOTIFIER_PROMPT_PROFILES lists %q, which is not in NOTIFIERS", name)
+		}
+		if _, dup := cfg.NotifierProfiles[name]; dup {
+			return nil, fmt.Errorf("NOTIFIER_PROMPT_PROFILES lists %q more than once", name)
+		}
+		cfg.NotifierProfiles[name] = profile
 	}
 
-	if cfg.DiscordWebhookURL == "" {
-		return nil, fmt.Errorf("DISCORD_WEBHOOK_URL is required")
+	for i, name := range cfg.NotifyRequired {
+		cfg.NotifyRequired[i] = strings.ToLower(name)
+		if !listed[cfg.NotifyRequired[i]] {
+			return nil, fmt.Errorf("NOTIFY_REQUIRED lists %q, which is not in NOTIFIERS", name)
+		}
+	}
+
+	switch cfg.NotifyPolicy {
+	case "any", "all":
+	default:
+		return nil, fmt.Errorf("NOTIFY_POLICY must be any or all, got %q", cfg.NotifyPolicy)
+	}
+
+	switch cfg.WriterBackend {
+	case "gemini", "openai", "none":
+	default:
+		return nil, fmt.Errorf("WRITER_BACKEND must be gemini, openai or none, got %q", cfg.WriterBackend)
+	}
+
+	switch cfg.WriterFallback {
+	case "", "openai", "gemini":
+	default:
+		return nil, fmt.Errorf("WRITER_FALLBACK_BACKEND must be empty, gemini or openai, got %q", cfg.WriterFallback)
+	}
+
+	if cfg.GeminiAPIKey != "" {
+		cfg.GeminiAPIKeys = append([]string{cfg.GeminiAPIKey}, cfg.GeminiAPIKeys...)
 	}
 
 	if cfg.RequestTimeout <= 0 {
@@ -58,6 +305,14 @@ func Load() (*Config, error) {
 		cfg.GeminiTopicLimit = defaultGeminiTopicLimit
 	}
 
+	if cfg.ArticleMaxBytes <= 0 {
+		cfg.ArticleMaxBytes = defaultArticleMaxBytes
+	}
+
+	if cfg.ArticleMaxChars <= 0 {
+		cfg.ArticleMaxChars = defaultArticleMaxChars
+	}
+
 	return cfg, nil
 }
 
@@ -77,6 +332,27 @@ func parseIntDefault(key string, fallback int) int {
 	return fallback
 }
 
+func parseBoolDefault(key string, fallback bool) bool {
+	if val := os.Getenv(key); val != "" {
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+func parseListDefault(key, fallback string) []string {
+	raw := getenvDefault(key, fallback)
+	parts := strings.Split(raw, ",")
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
 func parseDurationDefault(key string, fallback time.Duration) time.Duration {
 	if val := os.Getenv(key); val != "" {
 		if d, err := time.ParseDuration(val); err == nil {
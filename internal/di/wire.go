@@ -3,16 +3,34 @@
 package di
 
 import (
+	"context"
+	"expvar"
+	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/google/wire"
+	"github.com/robfig/cron/v3"
 
 	"bot-viethoang/internal/adapter/articles"
 	"bot-viethoang/internal/adapter/discord"
+	"bot-viethoang/internal/adapter/email"
+	"bot-viethoang/internal/adapter/filestore"
+	"bot-viethoang/internal/adapter/gemini"
+	"bot-viethoang/internal/adapter/history"
 	"bot-viethoang/internal/adapter/leetcode"
 	"bot-viethoang/internal/adapter/logging"
+	"bot-viethoang/internal/adapter/notify"
+	"bot-viethoang/internal/adapter/slack"
+	"bot-viethoang/internal/adapter/telegram"
+	"bot-viethoang/internal/adapter/usage"
 	"bot-viethoang/internal/adapter/writing"
+	"bot-viethoang/internal/adapter/youtube"
+	"bot-viethoang/internal/adapter/zalo"
 	"bot-viethoang/internal/app"
 	"bot-viethoang/internal/config"
 	"bot-viethoang/internal/domain/ports"
@@ -20,20 +38,32 @@ import (
 )
 
 // InitializeApp wires the application components together.
-func InitializeApp() (*app.App, error) {
+func InitializeApp(flags config.Flags) (*app.App, error) {
 	wire.Build(
 		config.Load,
 		provideSlogLogger,
-		logging.New,
+		provideLogger,
 		wire.Bind(new(ports.Logger), new(*logging.SLogger)),
 		provideProblemProvider,
 		provideArticleProvider,
+		provideVideoProvider,
+		provideContentFetcher,
+		provideKnowledgeBase,
+		providePrompts,
+		provideValidator,
+		provideUsageTracker,
+		provideGeminiKeys,
 		provideArticleWriter,
 		provideNotifier,
+		provideRunStore,
 		usecase.NewDailyDigest,
+		provideHintRelease,
+		provideQuizReveal,
+		provideWeeklyRecap,
 		provideDigestConfig,
 		app.New,
 		provideSchedule,
+		provideMetricsServer,
 	)
 	return nil, nil
 }
@@ -45,34 +75,323 @@ func provideSlogLogger() *slog.Logger {
 	return slog.New(handler)
 }
 
+// provideLogger masks every configured credential in log output.
+func provideLogger(cfg *config.Config, logger *slog.Logger) *logging.SLogger {
+	secrets := []string{
+		cfg.OpenAIAPIKey,
+		cfg.DiscordWebhookURL,
+		cfg.DiscordBotToken,
+		cfg.SlackWebhookURL,
+		cfg.TelegramBotToken,
+		cfg.SMTPPassword,
+		cfg.ZaloAppSecret,
+		cfg.ZaloAccessToken,
+		cfg.ZaloRefreshToken,
+	}
+	return logging.New(logger, append(secrets, cfg.GeminiAPIKeys...)...)
+}
+
 func provideProblemProvider(cfg *config.Config, logger ports.Logger) ports.ProblemProvider {
 	return leetcode.New(cfg.RequestTimeout, logger)
 }
 
-func provideArticleProvider(cfg *config.Config, logger ports.Logger) ports.ArticleProvider {
-	medium := articles.NewMediumProvider(cfg.RequestTimeout, logger)
-	devto := articles.NewDevToProvider(cfg.RequestTimeout, logger)
-	return articles.NewCompositeProvider(logger, medium, devto)
+func provideArticleProvider(cfg *config.Config, logger ports.Logger, pages ports.ArticleContentFetcher, tracker ports.UsageTracker, keys *gemini.KeyPool) ports.ArticleProvider {
+	rules := articles.FilterRules{
+		BlockedKeywords: cfg.BlockedKeywords,
+		BlockedAuthors:  cfg.BlockedAuthors,
+		MinTitleLength:  cfg.MinTitleLength,
+		MaxTitleLength:  cfg.MaxTitleLength,
+	}
+	mediumRules := rules
+	mediumRules.CheckPaywall = cfg.CheckPaywall
+
+	medium := articles.NewFilteredProvider("medium", articles.NewMediumProvider(cfg.RequestTimeout, logger), mediumRules, pages, logger)
+	devto := articles.NewFilteredProvider("devto", articles.NewDevToProvider(cfg.RequestTimeout, logger), rules, nil, logger)
+	var viblo ports.ArticleProvider
+	if len(cfg.VibloTags) > 0 {
+		viblo = articles.NewFilteredProvider("viblo", articles.NewVibloProvider(cfg.VibloTags, cfg.RequestTimeout, logger), rules, nil, logger)
+	}
+	var suggestions ports.ArticleProvider
+	if cfg.GeminiArticles && keys.Len() > 0 {
+		suggester := articles.NewGeminiProvider(keys, cfg.GeminiModel, cfg.RequestTimeout, cfg.GeminiTopicLimit, tracker, logger)
+		suggestions = articles.NewFilteredProvider("gemini", suggester, rules, nil, logger)
+	}
+	composite := articles.NewCompositeProvider(logger, medium, devto, viblo, suggestions)
+	if cfg.RequireVietnamese {
+		composite.RequireLanguage("vi")
+	}
+	return composite
+}
+
+func provideVideoProvider(cfg *config.Config, logger ports.Logger) ports.VideoProvider {
+	if len(cfg.YouTubeChannelIDs) == 0 && len(cfg.YouTubePlaylistIDs) == 0 {
+		return nil
+	}
+	return youtube.NewFeedProvider(cfg.YouTubeChannelIDs, cfg.YouTubePlaylistIDs, cfg.RequestTimeout, logger)
+}
+
+func provideContentFetcher(cfg *config.Config, logger ports.Logger) ports.ArticleContentFetcher {
+	return articles.NewContentFetcher(int64(cfg.ArticleMaxBytes), cfg.ArticleMaxChars, cfg.RequestTimeout, logger)
+}
+
+func provideKnowledgeBase(cfg *config.Config) (*writing.KnowledgeBase, error) {
+	return writing.NewKnowledgeBase(cfg.KnowledgeFile)
+}
+
+func providePrompts(cfg *config.Config, knowledge *writing.KnowledgeBase) (*writing.Prompts, error) {
+	return writing.NewPrompts(cfg.PromptDir, cfg.PromptProfile, cfg.TeamName, knowledge)
+}
+
+func provideUsageTracker(cfg *config.Config, logger ports.Logger) (ports.UsageTracker, error) {
+	store, err := filestore.New(filepath.Join(cfg.DataDir, "usage"))
+	if err != nil {
+		return nil, err
+	}
+	return usage.NewTracker(store, cfg.DailyTokenBudget, cfg.MonthlyTokenBudget, logger), nil
 }
 
-func provideArticleWriter(cfg *config.Config, logger ports.Logger) ports.ArticleWriter {
-	if cfg.GeminiAPIKey == "" {
+func provideValidator(cfg *config.Config) *writing.Validator {
+	if !cfg.ValidateOutput {
 		return nil
 	}
-	return writing.NewGeminiWriter(cfg.GeminiAPIKey, cfg.GeminiModel, cfg.RequestTimeout, logger)
+	return writing.NewValidator(cfg.LinkAllowlist)
+}
+
+// provideGeminiKeys builds the key pool shared by every Gemini client and publishes its health,
+// identified by fingerprints only, on the metrics endpoint.
+func provideGeminiKeys(cfg *config.Config, logger ports.Logger) *gemini.KeyPool {
+	keys := gemini.NewKeyPool(cfg.GeminiAPIKeys, cfg.GeminiKeyCooldown)
+	if keys.Len() > 0 {
+		expvar.Publish("gemini_keys", expvar.Func(func() any { return keys.Health() }))
+		logger.Info(context.Background(), "gemini key pool ready", "keys", keys.Health())
+	}
+	return keys
+}
+
+func provideArticleWriter(cfg *config.Config, flags config.Flags, logger ports.Logger, prompts *writing.Prompts, validator *writing.Validator, tracker ports.UsageTracker, keys *gemini.KeyPool) (ports.ArticleWriter, error) {
+	return newProfileWriter(cfg, flags, logger, prompts, validator, tracker, keys)
+}
+
+// newProfileWriter builds the writer chain for one prompt profile, cached under an identity that
+// includes the profile.
+func newProfileWriter(cfg *config.Config, flags config.Flags, logger ports.Logger, prompts *writing.Prompts, validator *writing.Validator, tracker ports.UsageTracker, keys *gemini.KeyPool) (ports.ArticleWriter, error) {
+	writer := newWriterChain(cfg, logger, prompts, validator, tracker, keys)
+	if writer == nil || cfg.InsightCacheTTL <= 0 {
+		return writer, nil
+	}
+	store, err := filestore.New(filepath.Join(cfg.DataDir, "insights"))
+	if err != nil {
+		return nil, err
+	}
+	return writing.NewCachedWriter(writer, store, writerIdentity(cfg, prompts.Profile()), cfg.InsightCacheTTL, flags.Regenerate, logger), nil
 }
 
-func provideNotifier(cfg *config.Config, logger ports.Logger) ports.Notifier {
-	return discord.NewWebhook(cfg.DiscordWebhookURL, cfg.RequestTimeout, logger)
+func newWriterChain(cfg *config.Config, logger ports.Logger, prompts *writing.Prompts, validator *writing.Validator, tracker ports.UsageTracker, keys *gemini.KeyPool) ports.ArticleWriter {
+	primary := newArticleWriter(cfg.WriterBackend, cfg, logger, prompts, validator, tracker, keys)
+	if cfg.WriterFallback == "" || cfg.WriterFallback == cfg.WriterBackend {
+		return primary
+	}
+	fallback := newArticleWriter(cfg.WriterFallback, cfg, logger, prompts, validator, tracker, keys)
+	if primary == nil {
+		return fallback
+	}
+	if fallback == nil {
+		return primary
+	}
+	return writing.NewFallbackWriter(logger, primary, fallback)
+}
+
+// writerIdentity names every backend and model that may answer, so changing the chain or the
+// prompt profile starts a fresh cache.
+func writerIdentity(cfg *config.Config, profile string) string {
+	identity := backendIdentity(cfg.WriterBackend, cfg)
+	if cfg.WriterFallback != "" && cfg.WriterFallback != cfg.WriterBackend {
+		identity += ">" + backendIdentity(cfg.WriterFallback, cfg)
+	}
+	return identity + "|" + profile
 }
 
-func provideDigestConfig(cfg *config.Config) usecase.DailyDigestConfig {
+func backendIdentity(backend string, cfg *config.Config) string {
+	if backend == "openai" {
+		return backend + ":" + cfg.OpenAIModel
+	}
+	return backend + ":" + strings.Join(append([]string{cfg.GeminiModel}, cfg.GeminiFallbacks...), ",")
+}
+
+func newArticleWriter(backend string, cfg *config.Config, logger ports.Logger, prompts *writing.Prompts, validator *writing.Validator, tracker ports.UsageTracker, keys *gemini.KeyPool) ports.ArticleWriter {
+	switch backend {
+	case "openai":
+		return writing.NewOpenAIWriter(cfg.OpenAIBaseURL, cfg.OpenAIAPIKey, cfg.OpenAIModel, prompts, validator, cfg.RequestTimeout, tracker, logger)
+	case "gemini":
+		if keys.Len() == 0 {
+			return nil
+		}
+		models := append([]string{cfg.GeminiModel}, cfg.GeminiFallbacks...)
+		return writing.NewGeminiWriter(keys, models, prompts, validator, cfg.WriterBackoff, cfg.RequestTimeout, tracker, logger)
+	default:
+		return nil
+	}
+}
+
+// provideNotifier fans notifications out to every destination listed in NOTIFIERS. Destinations
+// mapped to another prompt profile by NOTIFIER_PROMPT_PROFILES receive that profile's edition.
+func provideNotifier(cfg *config.Config, logger ports.Logger) (ports.Notifier, error) {
+	destinations := make([]notify.Destination, 0, len(cfg.Notifiers))
+	for _, name := range cfg.Notifiers {
+		notifier, err := newNotifier(name, cfg, logger)
+		if err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, notify.Destination{Name: name, Notifier: notifier, Profile: editionProfile(cfg, name)})
+	}
+	return notify.NewComposite(notify.Policy(cfg.NotifyPolicy), logger, destinations...).Require(cfg.NotifyRequired...), nil
+}
+
+func newNotifier(name string, cfg *config.Config, logger ports.Logger) (ports.Notifier, error) {
+	switch name {
+	case "discord":
+		return discord.NewWebhook(cfg.DiscordWebhookURL, cfg.RequestTimeout, logger), nil
+	case "slack":
+		return slack.NewWebhook(cfg.SlackWebhookURL, cfg.RequestTimeout, logger), nil
+	case "telegram":
+		return telegram.NewBot(cfg.TelegramBaseURL, cfg.TelegramBotToken, cfg.TelegramChatIDs, cfg.RequestTimeout, logger), nil
+	case "zalo":
+		// Rotated tokens are kept under DATA_DIR/zalo because Zalo refresh tokens are single use.
+		store, err := filestore.New(filepath.Join(cfg.DataDir, "zalo"))
+		if err != nil {
+			return nil, err
+		}
+		return zalo.NewOA(zalo.Config{
+			APIURL:       cfg.ZaloAPIURL,
+			OAuthURL:     cfg.ZaloOAuthURL,
+			AppID:        cfg.ZaloAppID,
+			AppSecret:    cfg.ZaloAppSecret,
+			AccessToken:  cfg.ZaloAccessToken,
+			RefreshToken: cfg.ZaloRefreshToken,
+			UserIDs:      cfg.ZaloUserIDs,
+			Timeout:      cfg.RequestTimeout,
+		}, store, logger), nil
+	case "email":
+		return email.NewSMTP(email.Config{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+			To:       cfg.EmailTo,
+			TLS:      cfg.SMTPTLS,
+			Timeout:  cfg.RequestTimeout,
+		}, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", name)
+	}
+}
+
+func provideRunStore(cfg *config.Config) (ports.RunStore, error) {
+	files, err := filestore.New(filepath.Join(cfg.DataDir, "runs"))
+	if err != nil {
+		return nil, err
+	}
+	return history.New(files), nil
+}
+
+func provideHintRelease(cfg *config.Config, problems ports.ProblemProvider, writer ports.ArticleWriter, runs ports.RunStore, notifier ports.Notifier, logger ports.Logger) *usecase.HintRelease {
+	if !cfg.HintLadder {
+		return nil
+	}
+	return usecase.NewHintRelease(problems, writer, runs, notifier, logger)
+}
+
+func provideQuizReveal(cfg *config.Config, runs ports.RunStore, notifier ports.Notifier, logger ports.Logger) *usecase.QuizReveal {
+	if !cfg.QuizEnabled {
+		return nil
+	}
+	return usecase.NewQuizReveal(runs, notifier, logger)
+}
+
+func provideWeeklyRecap(cfg *config.Config, runs ports.RunStore, writer ports.ArticleWriter, notifier ports.Notifier, logger ports.Logger) *usecase.WeeklyRecap {
+	if !cfg.WeeklyRecap {
+		return nil
+	}
+	return usecase.NewWeeklyRecap(runs, writer, notifier, logger)
+}
+
+func provideDigestConfig(cfg *config.Config, flags config.Flags, logger ports.Logger, knowledge *writing.KnowledgeBase, validator *writing.Validator, tracker ports.UsageTracker, keys *gemini.KeyPool) (usecase.DailyDigestConfig, error) {
+	writers, err := provideProfileWriters(cfg, flags, logger, knowledge, validator, tracker, keys)
+	if err != nil {
+		return usecase.DailyDigestConfig{}, err
+	}
 	return usecase.DailyDigestConfig{
-		RandomCount:  cfg.RandomProblemCount,
-		ArticleCount: cfg.ArticleCount,
+		RandomCount:       cfg.RandomProblemCount,
+		ArticleCount:      cfg.ArticleCount,
+		VideoCount:        cfg.VideoCount,
+		SummarizeArticles: cfg.SummarizeArticles,
+		HintLadder:        cfg.HintLadder,
+		Quiz:              cfg.QuizEnabled,
+		QuizClosesAt:      quizClosesAt(cfg.QuizRevealCron),
+		ProfileWriters:    writers,
+	}, nil
+}
+
+// provideProfileWriters builds one writer per prompt profile named in NOTIFIER_PROMPT_PROFILES
+// other than PROMPT_PROFILE. Profiles missing a template fall back to the default ones.
+func provideProfileWriters(cfg *config.Config, flags config.Flags, logger ports.Logger, knowledge *writing.KnowledgeBase, validator *writing.Validator, tracker ports.UsageTracker, keys *gemini.KeyPool) (map[string]ports.ArticleWriter, error) {
+	writers := make(map[string]ports.ArticleWriter)
+	for _, name := range cfg.Notifiers {
+		profile := editionProfile(cfg, name)
+		if _, built := writers[profile]; profile == "" || built {
+			continue
+		}
+		prompts, err := writing.NewPrompts(cfg.PromptDir, profile, cfg.TeamName, knowledge)
+		if err != nil {
+			return nil, fmt.Errorf("load prompt profile %s: %w", profile, err)
+		}
+		writer, err := newProfileWriter(cfg, flags, logger, prompts, validator, tracker, keys)
+		if err != nil {
+			return nil, err
+		}
+		if writer != nil {
+			writers[profile] = writer
+		}
+	}
+	return writers, nil
+}
+
+// editionProfile returns the prompt profile a notifier's digest is written with, or "" when it is
+// PROMPT_PROFILE.
+func editionProfile(cfg *config.Config, notifier string) string {
+	if profile := cfg.NotifierProfiles[notifier]; profile != cfg.PromptProfile {
+		return profile
 	}
+	return ""
 }
 
-func provideSchedule(cfg *config.Config) string {
-	return cfg.ScheduleCron
+// quizClosesAt closes each quiz poll when QUIZ_REVEAL_CRON next announces the answer. An invalid
+// expression is reported when the reveal job is scheduled.
+func quizClosesAt(spec string) func(now time.Time) time.Time {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil
+	}
+	return schedule.Next
+}
+
+func provideSchedule(cfg *config.Config) app.Schedules {
+	return app.Schedules{
+		Digest:      cfg.ScheduleCron,
+		Hint1:       cfg.Hint1Cron,
+		Hint2:       cfg.Hint2Cron,
+		Approach:    cfg.ApproachCron,
+		QuizReveal:  cfg.QuizRevealCron,
+		WeeklyRecap: cfg.WeeklyRecapCron,
+	}
+}
+
+func provideMetricsServer(cfg *config.Config) app.Server {
+	if cfg.MetricsAddr == "" {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	return &http.Server{Addr: cfg.MetricsAddr, Handler: mux}
 }
@@ -1,8 +1,36 @@
 package model
 
+import "time"
+
 // Article represents an article or blog post about algorithms.
 type Article struct {
-	Title  string
-	Link   string
-	Source string
+	Title       string
+	Link        string
+	Source      string
+	Author      string
+	Summary     string
+	PublishedAt time.Time
+	ReadingTime int // estimated minutes, 0 when unknown
+	Tags        []string
+	Reactions   int
+	CoverImage  string
+	Language    string // ISO 639-1 code, empty when unknown
+	MemberOnly  bool
+	Content     string // extracted main text, empty until fetched
+	TLDR        string
+	Relevance   string // why the article matters for today's problem
+}
+
+// ArticleSummary is a short digest of an article written for the daily problem.
+type ArticleSummary struct {
+	TLDR      string
+	Relevance string
+}
+
+// Age returns how long ago the article was published, or zero when the date is unknown.
+func (a Article) Age(now time.Time) time.Duration {
+	if a.PublishedAt.IsZero() || now.Before(a.PublishedAt) {
+		return 0
+	}
+	return now.Sub(a.PublishedAt)
 }
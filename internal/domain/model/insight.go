@@ -0,0 +1,17 @@
+package model
+
+// Per-section length budgets, in characters, that keep each insight embed field within Discord limits.
+const (
+	AnalysisLimit   = 700
+	ConceptLimit    = 600
+	StudyPlanLimit  = 700
+	ComplexityLimit = 200
+)
+
+// Insight is the writer's commentary on the daily digest, split into sections.
+type Insight struct {
+	Analysis   string
+	Concept    string
+	StudyPlan  string
+	Complexity string
+}
@@ -12,4 +12,9 @@ type Notification struct {
 	Title       string
 	Description string
 	Fields      []NotificationField
+	// Poll, when set, is posted as a native poll; notifiers without polls render it as text.
+	Poll *Poll
+	// Editions holds the same notification written with other prompt profiles, keyed by profile.
+	// Fan-out notifiers send a destination its profile's edition when one exists.
+	Editions map[string]Notification
 }
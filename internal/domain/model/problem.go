@@ -9,4 +9,13 @@ type Problem struct {
 	Link       string
 	Content    string
 	Topics     []string
+	GoSnippet  string // LeetCode's Go starter code, empty when unavailable
+	Examples   []Example
+}
+
+// Example is one sample test case from the problem statement.
+// Input holds one JSON-like literal per argument, as LeetCode lists them.
+type Example struct {
+	Input  []string
+	Output string
 }
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Quiz is a multiple-choice question about the daily problem. Answer indexes Options.
+type Quiz struct {
+	Question    string
+	Options     []string
+	Answer      int
+	Explanation string
+	// RevealAt is when the poll closes and the answer is due; zero means the day's reveal job.
+	RevealAt time.Time
+	// RevealedAt is set once the answer has been posted.
+	RevealedAt time.Time
+}
+
+// Poll is a native poll attached to a notification.
+type Poll struct {
+	Question string
+	Answers  []string
+	Duration time.Duration
+}
@@ -0,0 +1,8 @@
+package model
+
+// WeeklyRecap is the writer's review of the problems posted during one week.
+type WeeklyRecap struct {
+	Patterns    string
+	Pitfalls    string
+	Connections string
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// RunDateLayout formats the calendar day a run belongs to.
+const RunDateLayout = "2006-01-02"
+
+// HintLadder is a sequence of progressive hints ending with the approach summary.
+type HintLadder struct {
+	Hints    []string
+	Approach string
+}
+
+// DailyRun records what the bot produced and posted for one day.
+type DailyRun struct {
+	Date     string
+	PostedAt time.Time
+	Daily    *Problem
+	Random   []Problem
+	Articles []Article
+	Insight  Insight
+	Hints    *HintLadder
+	Quiz     *Quiz
+	// Usage counts the LLM tokens spent by the digest run that saved this record.
+	Usage TokenTotals
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// LLMUsage records the token consumption and latency of one LLM call.
+type LLMUsage struct {
+	At              time.Time
+	Backend         string
+	Model           string
+	Operation       string
+	PromptTokens    int
+	CandidateTokens int
+	TotalTokens     int
+	Latency         time.Duration
+}
+
+// TokenTotals sums the tokens of several LLM calls.
+type TokenTotals struct {
+	Calls           int
+	PromptTokens    int
+	CandidateTokens int
+	TotalTokens     int
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// VerificationResult reports how a solution fared against a problem's examples.
+type VerificationResult struct {
+	Passed   bool
+	Examples int
+	Output   string
+	Duration time.Duration
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// Video represents a video walkthrough related to a problem.
+type Video struct {
+	Title       string
+	Link        string
+	Channel     string
+	PublishedAt time.Time
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+// ArticleContentFetcher downloads an article and extracts its readable main text.
+type ArticleContentFetcher interface {
+	FetchContent(ctx context.Context, article model.Article) (string, error)
+}
@@ -8,5 +8,9 @@ import (
 
 // ArticleWriter synthesizes narrative content from problems and articles.
 type ArticleWriter interface {
-	Compose(ctx context.Context, daily *model.Problem, random []model.Problem, articles []model.Article) (string, error)
+	Compose(ctx context.Context, daily *model.Problem, random []model.Problem, articles []model.Article) (model.Insight, error)
+	SummarizeArticle(ctx context.Context, daily *model.Problem, article model.Article) (model.ArticleSummary, error)
+	WriteHints(ctx context.Context, daily *model.Problem) (model.HintLadder, error)
+	WriteQuiz(ctx context.Context, daily *model.Problem) (model.Quiz, error)
+	WriteRecap(ctx context.Context, runs []model.DailyRun) (model.WeeklyRecap, error)
 }
@@ -2,6 +2,9 @@ package ports
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"bot-viethoang/internal/domain/model"
 )
@@ -10,3 +13,37 @@ import (
 type Notifier interface {
 	Send(ctx context.Context, notification model.Notification) error
 }
+
+// Delivery is the outcome of sending one notification to one destination.
+type Delivery struct {
+	Destination string
+	Err         error
+	Latency     time.Duration
+}
+
+// DeliveryError is returned by fan-out notifiers when the delivery policy is not met. It carries
+// the outcome of every destination, including the successful ones.
+type DeliveryError struct {
+	Deliveries []Delivery
+}
+
+func (e *DeliveryError) Error() string {
+	failed := make([]string, 0, len(e.Deliveries))
+	for _, delivery := range e.Deliveries {
+		if delivery.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", delivery.Destination, delivery.Err))
+		}
+	}
+	return fmt.Sprintf("notification failed for %d of %d destinations: %s", len(failed), len(e.Deliveries), strings.Join(failed, "; "))
+}
+
+// Unwrap exposes the per-destination errors to errors.Is and errors.As.
+func (e *DeliveryError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Deliveries))
+	for _, delivery := range e.Deliveries {
+		if delivery.Err != nil {
+			errs = append(errs, delivery.Err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+// RunStore persists daily runs so later jobs can reuse what was generated in the morning.
+type RunStore interface {
+	SaveRun(ctx context.Context, run model.DailyRun) error
+	// GetRun returns nil without error when no run exists for the date.
+	GetRun(ctx context.Context, date string) (*model.DailyRun, error)
+	// ListRuns returns runs whose date falls within [from, to], oldest first.
+	ListRuns(ctx context.Context, from, to string) ([]model.DailyRun, error)
+}
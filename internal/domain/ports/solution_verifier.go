@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+// SolutionVerifier runs a Go solution against the problem's examples. Only cmd/checksolution uses
+// it; the scheduled jobs post no solutions to verify.
+type SolutionVerifier interface {
+	Verify(ctx context.Context, problem *model.Problem, source string) (model.VerificationResult, error)
+}
@@ -0,0 +1,58 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+// ErrBudgetExceeded is returned by UsageTracker.Allow once the configured token budget is spent.
+var ErrBudgetExceeded = errors.New("llm token budget exceeded")
+
+// UsageTracker accounts LLM token usage and enforces token budgets.
+type UsageTracker interface {
+	// Allow returns an error wrapping ErrBudgetExceeded when no further calls may be made.
+	Allow(ctx context.Context) error
+	// Record stores a call and adds it to the UsageMeter carried by ctx, if any.
+	Record(ctx context.Context, usage model.LLMUsage) error
+}
+
+// UsageMeter sums the LLM calls recorded under one context, such as a single digest run, so
+// concurrent jobs on the same day do not count each other's tokens.
+type UsageMeter struct {
+	mu     sync.Mutex
+	totals model.TokenTotals
+}
+
+type usageMeterKey struct{}
+
+// WithUsageMeter returns a context whose recorded LLM calls are summed by the returned meter.
+func WithUsageMeter(ctx context.Context) (context.Context, *UsageMeter) {
+	meter := &UsageMeter{}
+	return context.WithValue(ctx, usageMeterKey{}, meter), meter
+}
+
+// UsageMeterFrom returns the meter attached by WithUsageMeter, or nil.
+func UsageMeterFrom(ctx context.Context) *UsageMeter {
+	meter, _ := ctx.Value(usageMeterKey{}).(*UsageMeter)
+	return meter
+}
+
+// Add counts one call.
+func (m *UsageMeter) Add(call model.LLMUsage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.totals.Calls++
+	m.totals.PromptTokens += call.PromptTokens
+	m.totals.CandidateTokens += call.CandidateTokens
+	m.totals.TotalTokens += call.TotalTokens
+}
+
+// Totals returns the calls counted so far.
+func (m *UsageMeter) Totals() model.TokenTotals {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.totals
+}
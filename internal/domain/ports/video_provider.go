@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+// VideoProvider finds video walkthroughs for a given problem.
+type VideoProvider interface {
+	FindVideos(ctx context.Context, problem *model.Problem, count int) ([]model.Video, error)
+}
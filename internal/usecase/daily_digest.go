@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -10,46 +13,89 @@ import (
 	"bot-viethoang/internal/domain/ports"
 )
 
+const (
+	// fieldValueLimit is Discord's limit for one embed field value.
+	fieldValueLimit = 1024
+	// minTitleRunes and minExtraRunes keep shortened reading-list entries readable.
+	minTitleRunes = 20
+	minExtraRunes = 40
+)
+
 // DailyDigest orchestrates fetching problems and articles, then sending a notification.
 type DailyDigest struct {
 	problems     ports.ProblemProvider
 	articles     ports.ArticleProvider
+	videos       ports.VideoProvider
+	content      ports.ArticleContentFetcher
 	writer       ports.ArticleWriter
 	notifier     ports.Notifier
+	runs         ports.RunStore
 	logger       ports.Logger
 	randomCount  int
 	articleCount int
+	videoCount   int
+	summarize    bool
+	hintLadder   bool
+	quiz         bool
+	quizClosesAt func(now time.Time) time.Time
+	profiles     map[string]ports.ArticleWriter
 }
 
 // DailyDigestConfig controls optional behaviours for the digest.
 type DailyDigestConfig struct {
-	RandomCount  int
-	ArticleCount int
+	RandomCount       int
+	ArticleCount      int
+	VideoCount        int
+	SummarizeArticles bool
+	// HintLadder withholds the approach from the morning digest and prepares hints for later jobs.
+	HintLadder bool
+	// Quiz posts a multiple-choice poll about the daily problem after the digest.
+	Quiz bool
+	// QuizClosesAt returns when a poll posted at now should close, normally the next answer reveal.
+	QuizClosesAt func(now time.Time) time.Time
+	// ProfileWriters write extra editions of the digest for destinations that use another prompt
+	// profile, keyed by profile name.
+	ProfileWriters map[string]ports.ArticleWriter
 }
 
 // NewDailyDigest constructs a DailyDigest use case.
 func NewDailyDigest(
 	problems ports.ProblemProvider,
 	articles ports.ArticleProvider,
+	videos ports.VideoProvider,
+	content ports.ArticleContentFetcher,
 	writer ports.ArticleWriter,
 	notifier ports.Notifier,
+	runs ports.RunStore,
 	logger ports.Logger,
 	cfg DailyDigestConfig,
 ) *DailyDigest {
 	return &DailyDigest{
 		problems:     problems,
 		articles:     articles,
+		videos:       videos,
+		content:      content,
 		writer:       writer,
 		notifier:     notifier,
+		runs:         runs,
 		logger:       logger,
 		randomCount:  cfg.RandomCount,
 		articleCount: cfg.ArticleCount,
+		videoCount:   cfg.VideoCount,
+		summarize:    cfg.SummarizeArticles,
+		hintLadder:   cfg.HintLadder,
+		quiz:         cfg.Quiz,
+		quizClosesAt: cfg.QuizClosesAt,
+		profiles:     cfg.ProfileWriters,
 	}
 }
 
 // Run executes the daily digest workflow.
 func (d *DailyDigest) Run(ctx context.Context) error {
 	start := time.Now()
+	date := start.Format(model.RunDateLayout)
+	// The meter counts only this run's writer calls; other jobs may spend tokens on the same day.
+	ctx, meter := ports.WithUsageMeter(ctx)
 	d.logger.Info(ctx, "starting daily digest")
 
 	daily, err := d.problems.GetDailyChallenge(ctx)
@@ -59,15 +105,32 @@ func (d *DailyDigest) Run(ctx context.Context) error {
 	}
 
 	randomProblems := d.fetchRandomProblems(ctx, daily)
-	articles := d.fetchArticles(ctx)
-	insight := d.composeInsight(ctx, daily, randomProblems, articles)
+	articles := d.enrichArticles(ctx, d.writer, daily, d.fetchArticles(ctx))
+	videos := d.fetchVideos(ctx, daily)
+	insight := d.composeInsight(ctx, d.writer, daily, randomProblems, articles)
 
-	notification := d.buildNotification(daily, randomProblems, articles, insight)
+	notification := d.buildNotification(daily, randomProblems, articles, videos, insight)
+	notification.Editions = d.buildEditions(ctx, daily, randomProblems, articles, videos)
 	if err := d.notifier.Send(ctx, notification); err != nil {
 		d.logger.Error(ctx, "failed to send notification", "error", err)
 		return err
 	}
 
+	previous := d.loadRun(ctx, date)
+	hints := d.writeHints(ctx, daily, previous)
+	quiz := d.postQuiz(ctx, daily, previous)
+	d.recordRun(ctx, model.DailyRun{
+		Date:     date,
+		PostedAt: time.Now(),
+		Daily:    daily,
+		Random:   randomProblems,
+		Articles: articles,
+		Insight:  insight,
+		Hints:    hints,
+		Quiz:     quiz,
+		Usage:    meter.Totals(),
+	})
+
 	d.logger.Info(ctx, "daily digest completed", "duration", time.Since(start))
 	return nil
 }
@@ -119,21 +182,182 @@ func (d *DailyDigest) fetchArticles(ctx context.Context) []model.Article {
 	return articles
 }
 
-func (d *DailyDigest) composeInsight(ctx context.Context, daily *model.Problem, random []model.Problem, articles []model.Article) string {
-	if d.writer == nil {
-		return ""
+func (d *DailyDigest) enrichArticles(ctx context.Context, writer ports.ArticleWriter, daily *model.Problem, articles []model.Article) []model.Article {
+	if !d.summarize || writer == nil {
+		return articles
+	}
+
+	for i := range articles {
+		if d.content != nil && articles[i].Content == "" {
+			content, err := d.content.FetchContent(ctx, articles[i])
+			if err != nil {
+				d.logger.Info(ctx, "article content unavailable", "link", articles[i].Link, "error", err)
+			} else {
+				articles[i].Content = content
+			}
+		}
+
+		if articles[i].Content == "" && articles[i].Summary == "" {
+			continue
+		}
+
+		summary, err := writer.SummarizeArticle(ctx, daily, articles[i])
+		if errors.Is(err, ports.ErrBudgetExceeded) {
+			d.logger.Info(ctx, "llm budget exhausted, skipping article summaries", "error", err)
+			return articles
+		}
+		if err != nil {
+			d.logger.Error(ctx, "failed to summarize article", "link", articles[i].Link, "error", err)
+			continue
+		}
+		articles[i].TLDR = summary.TLDR
+		articles[i].Relevance = summary.Relevance
+	}
+
+	return articles
+}
+
+func (d *DailyDigest) fetchVideos(ctx context.Context, daily *model.Problem) []model.Video {
+	if d.videos == nil || d.videoCount <= 0 || daily == nil {
+		return nil
+	}
+
+	videos, err := d.videos.FindVideos(ctx, daily, d.videoCount)
+	if err != nil {
+		d.logger.Error(ctx, "failed to fetch videos", "error", err)
+		return nil
 	}
 
-	insight, err := d.writer.Compose(ctx, daily, random, articles)
+	return videos
+}
+
+func (d *DailyDigest) composeInsight(ctx context.Context, writer ports.ArticleWriter, daily *model.Problem, random []model.Problem, articles []model.Article) model.Insight {
+	if writer == nil {
+		return model.Insight{}
+	}
+
+	insight, err := writer.Compose(ctx, daily, random, articles)
+	if errors.Is(err, ports.ErrBudgetExceeded) {
+		d.logger.Info(ctx, "llm budget exhausted, using fallback description", "error", err)
+		return model.Insight{}
+	}
 	if err != nil {
 		d.logger.Error(ctx, "failed to compose insight", "error", err)
-		return ""
+		return model.Insight{}
+	}
+
+	return insight
+}
+
+// buildEditions writes the digest again for every other prompt profile. Fetched article content is
+// reused; summaries and the insight come from that profile's writer.
+func (d *DailyDigest) buildEditions(ctx context.Context, daily *model.Problem, random []model.Problem, articles []model.Article, videos []model.Video) map[string]model.Notification {
+	if len(d.profiles) == 0 {
+		return nil
+	}
+
+	editions := make(map[string]model.Notification, len(d.profiles))
+	for _, profile := range slices.Sorted(maps.Keys(d.profiles)) {
+		writer := d.profiles[profile]
+		profileArticles := slices.Clone(articles)
+		for i := range profileArticles {
+			profileArticles[i].TLDR, profileArticles[i].Relevance = "", ""
+		}
+		profileArticles = d.enrichArticles(ctx, writer, daily, profileArticles)
+		insight := d.composeInsight(ctx, writer, daily, random, profileArticles)
+		editions[profile] = d.buildNotification(daily, random, profileArticles, videos, insight)
+		d.logger.Info(ctx, "digest edition written", "profile", profile)
+	}
+	return editions
+}
+
+// writeHints prepares today's hint ladder, reusing one stored by an earlier run so hints already
+// released stay consistent with the rest of the ladder.
+func (d *DailyDigest) writeHints(ctx context.Context, daily *model.Problem, previous *model.DailyRun) *model.HintLadder {
+	if !d.hintLadder || d.writer == nil {
+		return nil
+	}
+	if previous != nil && previous.Hints != nil {
+		return previous.Hints
+	}
+
+	ladder, err := d.writer.WriteHints(ctx, daily)
+	if err != nil {
+		d.logger.Error(ctx, "failed to write hint ladder", "error", err)
+		return nil
+	}
+	return &ladder
+}
+
+// postQuiz writes today's quiz and posts it as a poll. The answer is kept for the reveal job. A
+// quiz already stored for today, from a run before a restart, is kept instead of posting again.
+func (d *DailyDigest) postQuiz(ctx context.Context, daily *model.Problem, previous *model.DailyRun) *model.Quiz {
+	if !d.quiz || d.writer == nil {
+		return nil
+	}
+	if previous != nil && previous.Quiz != nil {
+		d.logger.Info(ctx, "quiz already posted today, skipping", "date", previous.Date)
+		return previous.Quiz
+	}
+
+	quiz, err := d.writer.WriteQuiz(ctx, daily)
+	if err != nil {
+		d.logger.Error(ctx, "failed to write quiz", "error", err)
+		return nil
+	}
+
+	now := time.Now()
+	if d.quizClosesAt != nil {
+		// The poll may close on a later day than the run's date; the reveal job looks it up by this.
+		quiz.RevealAt = d.quizClosesAt(now)
+	}
+	notification := model.Notification{
+		Title:       "🧠 Quiz – " + daily.Title,
+		Description: "Chọn đáp án của bạn, lời giải sẽ được công bố khi poll kết thúc.",
+		Poll: &model.Poll{
+			Question: quiz.Question,
+			Answers:  quiz.Options,
+			Duration: d.pollDuration(now),
+		},
+	}
+	if err := d.notifier.Send(ctx, notification); err != nil {
+		d.logger.Error(ctx, "failed to post quiz", "error", err)
+		return nil
+	}
+	return &quiz
+}
+
+// pollDuration keeps the poll open until the answer is revealed. Polls last at least an hour.
+func (d *DailyDigest) pollDuration(now time.Time) time.Duration {
+	if d.quizClosesAt == nil {
+		return 0
+	}
+	return max(d.quizClosesAt(now).Sub(now), time.Hour)
+}
+
+// loadRun returns the run already stored for date, or nil.
+func (d *DailyDigest) loadRun(ctx context.Context, date string) *model.DailyRun {
+	if d.runs == nil {
+		return nil
+	}
+	run, err := d.runs.GetRun(ctx, date)
+	if err != nil {
+		d.logger.Error(ctx, "failed to load daily run", "date", date, "error", err)
+		return nil
 	}
+	return run
+}
 
-	return trimForDiscord(insight, 1900)
+func (d *DailyDigest) recordRun(ctx context.Context, run model.DailyRun) {
+	if d.runs == nil {
+		return
+	}
+	if err := d.runs.SaveRun(ctx, run); err != nil {
+		d.logger.Error(ctx, "failed to record daily run", "date", run.Date, "error", err)
+	}
 }
 
-func (d *DailyDigest) buildNotification(daily *model.Problem, random []model.Problem, articles []model.Article, insight string) model.Notification {
+func (d *DailyDigest) buildNotification(daily *model.Problem, random []model.Problem, articles []model.Article, videos []model.Video, insight model.Insight) model.Notification {
 	var fields []model.NotificationField
 
 	if daily != nil {
@@ -144,6 +368,20 @@ func (d *DailyDigest) buildNotification(daily *model.Problem, random []model.Pro
 		})
 	}
 
+	if d.hintLadder {
+		// The whole insight names the approach; the evening hint job reveals it instead.
+		insight = model.Insight{}
+	}
+	fields = append(fields, insightFields(insight)...)
+
+	if len(videos) > 0 {
+		fields = append(fields, model.NotificationField{
+			Name:   "Watch",
+			Value:  formatVideoList(videos),
+			Inline: false,
+		})
+	}
+
 	if len(random) > 0 {
 		fields = append(fields, model.NotificationField{
 			Name:   "Practice Queue",
@@ -155,25 +393,46 @@ func (d *DailyDigest) buildNotification(daily *model.Problem, random []model.Pro
 	if len(articles) > 0 {
 		fields = append(fields, model.NotificationField{
 			Name:   "Algorithm Reading List",
-			Value:  formatArticleList(articles),
+			Value:  formatArticleList(articles, time.Now()),
 			Inline: false,
 		})
 	}
 
-	description := "Here is your curated practice plan for today."
-	if insight != "" {
-		description = insight
-	} else {
-		description = fallbackDescription(daily)
-	}
-
 	return model.Notification{
 		Title:       "Daily LeetCode & Algorithms Digest",
-		Description: description,
+		Description: fallbackDescription(daily),
 		Fields:      fields,
 	}
 }
 
+// insightFields maps each insight section to its own field. Budgets keep every section under
+// Discord's 1024-character field limit and the whole embed under its 6000-character total.
+func insightFields(insight model.Insight) []model.NotificationField {
+	sections := []struct {
+		name   string
+		value  string
+		budget int
+	}{
+		{"🎯 Phân Tích Bài Toán", insight.Analysis, model.AnalysisLimit},
+		{"📚 Concept từ Grokking Algorithms", insight.Concept, model.ConceptLimit},
+		{"💡 Study Plan", insight.StudyPlan, model.StudyPlanLimit},
+		{"⏱️ Complexity", insight.Complexity, model.ComplexityLimit},
+	}
+
+	fields := make([]model.NotificationField, 0, len(sections))
+	for _, section := range sections {
+		if section.value == "" {
+			continue
+		}
+		fields = append(fields, model.NotificationField{
+			Name:   section.name,
+			Value:  trimForDiscord(section.value, section.budget),
+			Inline: false,
+		})
+	}
+	return fields
+}
+
 func formatProblemDetail(p *model.Problem) string {
 	var builder strings.Builder
 	builder.WriteString(fmt.Sprintf("**Link:** [%s](%s)\n", p.Title, p.Link))
@@ -192,29 +451,34 @@ func formatProblemDetail(p *model.Problem) string {
 	return builder.String()
 }
 
+// trimForDiscord shortens content to limit characters, cutting at the last whitespace so neither a
+// word nor a multi-byte rune is split.
 func trimForDiscord(content string, limit int) string {
-	if len(content) <= limit {
+	runes := []rune(content)
+	if len(runes) <= limit {
 		return content
 	}
-	trimmed := content[:limit]
-	lastSpace := strings.LastIndex(trimmed, " ")
-	if lastSpace > 0 {
-		trimmed = trimmed[:lastSpace]
+	trimmed := string(runes[:limit])
+	if cut := strings.LastIndexAny(trimmed, " \n"); cut > 0 {
+		trimmed = trimmed[:cut]
 	}
-	return trimmed + "..."
+	return strings.TrimRight(trimmed, " \n") + "..."
 }
 
+// summarizeText collapses whitespace and shortens content to limit characters (runes), cutting at
+// the last space.
 func summarizeText(content string, limit int) string {
 	clean := strings.Join(strings.Fields(content), " ")
 	if clean == "" {
 		return ""
 	}
 
-	if len(clean) <= limit {
+	runes := []rune(clean)
+	if len(runes) <= limit {
 		return clean
 	}
 
-	trimmed := clean[:limit]
+	trimmed := string(runes[:limit])
 	lastSpace := strings.LastIndex(trimmed, " ")
 	if lastSpace > 0 {
 		trimmed = trimmed[:lastSpace]
@@ -232,19 +496,102 @@ func formatPracticeQueue(problems []model.Problem) string {
 	return strings.Join(lines, "\n\n")
 }
 
-func formatArticleList(articles []model.Article) string {
+// formatArticleList splits Discord's field limit evenly between the articles so every entry is
+// shortened on its own instead of the field being cut in the middle of one.
+func formatArticleList(articles []model.Article, now time.Time) string {
+	if len(articles) == 0 {
+		return ""
+	}
+	budget := (fieldValueLimit - len("\n\n")*(len(articles)-1)) / len(articles)
+
 	lines := make([]string, 0, len(articles))
 	for i, a := range articles {
-		source := a.Source
-		if source == "" {
-			source = "Curated"
+		lines = append(lines, formatArticleEntry(i+1, a, now, budget))
+	}
+	return strings.Join(lines, "\n\n")
+}
+
+// formatArticleEntry renders one reading-list entry within budget characters. The TL;DR and the
+// relevance line shrink or are left out first; the title is shortened only when the header alone
+// does not fit.
+func formatArticleEntry(position int, a model.Article, now time.Time, budget int) string {
+	source := a.Source
+	if source == "" {
+		source = "Curated"
+	}
+	meta := []string{"from " + source}
+	if a.ReadingTime > 0 {
+		meta = append(meta, fmt.Sprintf("%d min read", a.ReadingTime))
+	}
+	if !a.PublishedAt.IsZero() {
+		meta = append(meta, humanizeAge(a.Age(now)))
+	}
+
+	header := func(title string) string {
+		return fmt.Sprintf("**%d.** [%s](%s)\n   _%s_", position, title, a.Link, strings.Join(meta, " · "))
+	}
+	entry := header(a.Title)
+	if over := runeCount(entry) - budget; over > 0 {
+		entry = header(trimForDiscord(a.Title, max(runeCount(a.Title)-over-len("..."), minTitleRunes)))
+	}
+
+	extras := []struct {
+		prefix string
+		text   string
+		limit  int
+	}{
+		{"\n   > ", a.TLDR, 220},
+		{"\n   💡 ", a.Relevance, 140},
+	}
+	for _, extra := range extras {
+		if extra.text == "" {
+			continue
+		}
+		room := budget - runeCount(entry) - runeCount(extra.prefix) - len("...")
+		if room < minExtraRunes {
+			continue
+		}
+		entry += extra.prefix + summarizeText(extra.text, min(extra.limit, room))
+	}
+	return entry
+}
+
+func runeCount(text string) int {
+	return len([]rune(text))
+}
+
+func formatVideoList(videos []model.Video) string {
+	lines := make([]string, 0, len(videos))
+	for _, v := range videos {
+		line := fmt.Sprintf("▶️ [%s](%s)", v.Title, v.Link)
+		if v.Channel != "" {
+			line += fmt.Sprintf("\n   _by %s_", v.Channel)
 		}
-		line := fmt.Sprintf("**%d.** [%s](%s)\n   _from %s_", i+1, a.Title, a.Link, source)
 		lines = append(lines, line)
 	}
 	return strings.Join(lines, "\n\n")
 }
 
+func humanizeAge(age time.Duration) string {
+	days := int(age.Hours() / 24)
+	switch {
+	case days < 1:
+		return "today"
+	case days == 1:
+		return "yesterday"
+	case days < 30:
+		return fmt.Sprintf("%d days ago", days)
+	case days < 60:
+		return "1 month ago"
+	case days < 365:
+		return fmt.Sprintf("%d months ago", days/30)
+	case days < 730:
+		return "1 year ago"
+	default:
+		return fmt.Sprintf("%d years ago", days/365)
+	}
+}
+
 func fallbackDescription(daily *model.Problem) string {
 	if daily == nil {
 		return "Curated plan for algorithms practice today."
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// voiceWriter writes every piece of text in one voice so tests can tell profiles apart.
+type voiceWriter struct {
+	ports.ArticleWriter
+	voice string
+}
+
+func (w voiceWriter) Compose(context.Context, *model.Problem, []model.Problem, []model.Article) (model.Insight, error) {
+	return model.Insight{Analysis: w.voice + " analysis"}, nil
+}
+
+func (w voiceWriter) SummarizeArticle(context.Context, *model.Problem, model.Article) (model.ArticleSummary, error) {
+	return model.ArticleSummary{TLDR: w.voice + " tldr"}, nil
+}
+
+func notificationText(notification model.Notification) string {
+	var text strings.Builder
+	for _, field := range notification.Fields {
+		text.WriteString(field.Value + "\n")
+	}
+	return text.String()
+}
+
+func TestBuildNotificationWithholdsInsightWithHintLadder(t *testing.T) {
+	daily := &model.Problem{Title: "Two Sum", Link: "https://leetcode.com/problems/two-sum/", Difficulty: "Easy"}
+	insight := model.Insight{
+		Analysis:   "Dùng hash map.",
+		Concept:    "Chapter 5 Hash Tables.",
+		StudyPlan:  "- Làm lại Two Sum",
+		Complexity: "O(n)",
+	}
+
+	for _, hintLadder := range []bool{false, true} {
+		digest := &DailyDigest{hintLadder: hintLadder}
+		notification := digest.buildNotification(daily, nil, nil, nil, insight)
+
+		text := notificationText(notification)
+		for _, section := range []string{insight.Analysis, insight.Concept, insight.StudyPlan, insight.Complexity} {
+			if got := strings.Contains(text, section); got == hintLadder {
+				t.Errorf("hintLadder=%v: digest shows %q = %v", hintLadder, section, got)
+			}
+		}
+	}
+}
+
+func TestBuildEditionsWritesEachProfileWithItsWriter(t *testing.T) {
+	daily := &model.Problem{Title: "Two Sum", Link: "https://leetcode.com/problems/two-sum/", Difficulty: "Easy"}
+	articles := []model.Article{{Title: "Hash maps", Link: "https://example.com/hash", Summary: "About hash maps.", TLDR: "default tldr"}}
+	digest := &DailyDigest{
+		summarize: true,
+		logger:    discardLogger{},
+		profiles:  map[string]ports.ArticleWriter{"formal": voiceWriter{voice: "formal"}},
+	}
+
+	editions := digest.buildEditions(context.Background(), daily, nil, articles, nil)
+
+	text := notificationText(editions["formal"])
+	for _, want := range []string{"formal analysis", "formal tldr"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("formal edition misses %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "default tldr") {
+		t.Errorf("formal edition kept the default summary:\n%s", text)
+	}
+	if articles[0].TLDR != "default tldr" {
+		t.Errorf("default articles changed to %q", articles[0].TLDR)
+	}
+}
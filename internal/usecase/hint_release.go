@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// HintRelease posts the day's hint ladder step by step as follow-up messages.
+type HintRelease struct {
+	problems ports.ProblemProvider
+	writer   ports.ArticleWriter
+	runs     ports.RunStore
+	notifier ports.Notifier
+	logger   ports.Logger
+}
+
+// NewHintRelease constructs a HintRelease use case.
+func NewHintRelease(
+	problems ports.ProblemProvider,
+	writer ports.ArticleWriter,
+	runs ports.RunStore,
+	notifier ports.Notifier,
+	logger ports.Logger,
+) *HintRelease {
+	return &HintRelease{
+		problems: problems,
+		writer:   writer,
+		runs:     runs,
+		notifier: notifier,
+		logger:   logger,
+	}
+}
+
+// ReleaseHint posts hint number step (1-based) from today's ladder.
+func (h *HintRelease) ReleaseHint(ctx context.Context, step int) error {
+	run, err := h.todaysLadder(ctx)
+	if err != nil {
+		return err
+	}
+
+	hints := run.Hints.Hints
+	if step < 1 || step > len(hints) {
+		return fmt.Errorf("hint %d out of range (ladder has %d)", step, len(hints))
+	}
+
+	notification := model.Notification{
+		Title:       fmt.Sprintf("💡 Hint %d/%d – %s", step, len(hints), run.Daily.Title),
+		Description: trimForDiscord(hints[step-1], 2000),
+		Fields: []model.NotificationField{
+			{Name: "Problem", Value: fmt.Sprintf("[%s](%s)", run.Daily.Title, run.Daily.Link)},
+		},
+	}
+	if err := h.notifier.Send(ctx, notification); err != nil {
+		return err
+	}
+
+	h.logger.Info(ctx, "hint released", "step", step, "date", run.Date)
+	return nil
+}
+
+// ReleaseApproach posts the remaining hints and the approach summary, with the approach
+// and the insight withheld from the morning digest hidden behind spoiler tags.
+func (h *HintRelease) ReleaseApproach(ctx context.Context, fromStep int) error {
+	run, err := h.todaysLadder(ctx)
+	if err != nil {
+		return err
+	}
+
+	var fields []model.NotificationField
+	for i := fromStep; i <= len(run.Hints.Hints); i++ {
+		fields = append(fields, model.NotificationField{
+			Name:  fmt.Sprintf("💡 Hint %d", i),
+			Value: trimForDiscord(run.Hints.Hints[i-1], 1000),
+		})
+	}
+	for _, field := range insightFields(run.Insight) {
+		field.Value = spoiler(field.Value)
+		fields = append(fields, field)
+	}
+	fields = append(fields, model.NotificationField{
+		Name:  "Problem",
+		Value: fmt.Sprintf("[%s](%s)", run.Daily.Title, run.Daily.Link),
+	})
+
+	notification := model.Notification{
+		Title:       fmt.Sprintf("✅ Approach – %s", run.Daily.Title),
+		Description: spoiler(trimForDiscord(run.Hints.Approach, 2000)),
+		Fields:      fields,
+	}
+	if err := h.notifier.Send(ctx, notification); err != nil {
+		return err
+	}
+
+	h.logger.Info(ctx, "approach released", "date", run.Date)
+	return nil
+}
+
+// todaysLadder loads today's run, generating and storing the ladder when the morning
+// digest did not (for example when the bot started after the digest was posted).
+func (h *HintRelease) todaysLadder(ctx context.Context) (*model.DailyRun, error) {
+	date := time.Now().Format(model.RunDateLayout)
+
+	var run *model.DailyRun
+	if h.runs != nil {
+		stored, err := h.runs.GetRun(ctx, date)
+		if err != nil {
+			h.logger.Error(ctx, "failed to load daily run", "date", date, "error", err)
+		}
+		run = stored
+	}
+	if run != nil && run.Hints != nil && run.Daily != nil {
+		return run, nil
+	}
+
+	if h.writer == nil {
+		return nil, fmt.Errorf("no hint ladder for %s and no writer configured", date)
+	}
+
+	if run == nil {
+		run = &model.DailyRun{Date: date}
+	}
+	if run.Daily == nil {
+		daily, err := h.problems.GetDailyChallenge(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fetch daily challenge: %w", err)
+		}
+		run.Daily = daily
+	}
+
+	ladder, err := h.writer.WriteHints(ctx, run.Daily)
+	if err != nil {
+		return nil, fmt.Errorf("write hint ladder: %w", err)
+	}
+	run.Hints = &ladder
+
+	if h.runs != nil {
+		if err := h.runs.SaveRun(ctx, *run); err != nil {
+			h.logger.Error(ctx, "failed to record daily run", "date", date, "error", err)
+		}
+	}
+	return run, nil
+}
+
+func spoiler(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "||" + text + "||"
+}
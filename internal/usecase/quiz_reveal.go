@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+// quizLookbackDays bounds how far back Reveal searches for quizzes whose answer is still pending.
+const quizLookbackDays = 7
+
+// QuizReveal posts the answer and explanation for the quiz published with the morning digest.
+type QuizReveal struct {
+	runs     ports.RunStore
+	notifier ports.Notifier
+	logger   ports.Logger
+	now      func() time.Time
+}
+
+// NewQuizReveal constructs a QuizReveal use case.
+func NewQuizReveal(runs ports.RunStore, notifier ports.Notifier, logger ports.Logger) *QuizReveal {
+	return &QuizReveal{
+		runs:     runs,
+		notifier: notifier,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Reveal announces the correct option of every quiz whose poll has closed and whose answer has
+// not been posted yet. A poll posted after the day's reveal time closes on a later day, so runs are
+// matched by their reveal target rather than by today's date.
+func (q *QuizReveal) Reveal(ctx context.Context) error {
+	now := q.now()
+	today := now.Format(model.RunDateLayout)
+	from := now.AddDate(0, 0, -quizLookbackDays).Format(model.RunDateLayout)
+
+	runs, err := q.runs.ListRuns(ctx, from, today)
+	if err != nil {
+		return fmt.Errorf("load daily runs: %w", err)
+	}
+
+	revealed := 0
+	for _, run := range runs {
+		if !quizDue(run, today, now) {
+			continue
+		}
+		if err := q.reveal(ctx, run); err != nil {
+			return fmt.Errorf("reveal quiz of %s: %w", run.Date, err)
+		}
+		revealed++
+	}
+	if revealed == 0 {
+		return fmt.Errorf("no quiz due for reveal on %s", today)
+	}
+	return nil
+}
+
+// quizDue reports whether the run holds an unrevealed quiz whose answer is due by now. The minute of
+// slack absorbs a reveal job that fires marginally before the stored poll close.
+func quizDue(run model.DailyRun, today string, now time.Time) bool {
+	if run.Quiz == nil || !run.Quiz.RevealedAt.IsZero() {
+		return false
+	}
+	if run.Quiz.RevealAt.IsZero() {
+		return run.Date == today
+	}
+	return !run.Quiz.RevealAt.After(now.Add(time.Minute))
+}
+
+func (q *QuizReveal) reveal(ctx context.Context, run model.DailyRun) error {
+	quiz := run.Quiz
+	if quiz.Answer < 0 || quiz.Answer >= len(quiz.Options) {
+		return fmt.Errorf("quiz answer index %d out of range", quiz.Answer)
+	}
+
+	fields := []model.NotificationField{
+		{Name: "Câu hỏi", Value: trimForDiscord(quiz.Question, 1000)},
+		{Name: "Đáp án", Value: fmt.Sprintf("✅ **%c.** %s", 'A'+quiz.Answer, quiz.Options[quiz.Answer])},
+	}
+	if run.Daily != nil {
+		fields = append(fields, model.NotificationField{
+			Name:  "Problem",
+			Value: fmt.Sprintf("[%s](%s)", run.Daily.Title, run.Daily.Link),
+		})
+	}
+
+	title := "🧠 Đáp án quiz"
+	if run.Daily != nil {
+		title += " – " + run.Daily.Title
+	}
+
+	notification := model.Notification{
+		Title:       title,
+		Description: trimForDiscord(quiz.Explanation, 2000),
+		Fields:      fields,
+	}
+	if err := q.notifier.Send(ctx, notification); err != nil {
+		return err
+	}
+
+	quiz.RevealedAt = q.now()
+	if err := q.runs.SaveRun(ctx, run); err != nil {
+		// The answer is out; a failed save only risks posting it again on the next reveal.
+		q.logger.Error(ctx, "failed to mark quiz as revealed", "date", run.Date, "error", err)
+	}
+
+	q.logger.Info(ctx, "quiz answer revealed", "date", run.Date)
+	return nil
+}
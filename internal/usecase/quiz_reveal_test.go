@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+)
+
+type memoryRunStore struct {
+	runs map[string]model.DailyRun
+}
+
+func (m *memoryRunStore) SaveRun(_ context.Context, run model.DailyRun) error {
+	m.runs[run.Date] = run
+	return nil
+}
+
+func (m *memoryRunStore) GetRun(_ context.Context, date string) (*model.DailyRun, error) {
+	run, ok := m.runs[date]
+	if !ok {
+		return nil, nil
+	}
+	return &run, nil
+}
+
+func (m *memoryRunStore) ListRuns(_ context.Context, from, to string) ([]model.DailyRun, error) {
+	var runs []model.DailyRun
+	for date, run := range m.runs {
+		if date >= from && date <= to {
+			runs = append(runs, run)
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Date < runs[j].Date })
+	return runs, nil
+}
+
+type recordingNotifier struct {
+	sent []model.Notification
+}
+
+func (r *recordingNotifier) Send(_ context.Context, notification model.Notification) error {
+	r.sent = append(r.sent, notification)
+	return nil
+}
+
+type discardLogger struct{}
+
+func (discardLogger) Info(context.Context, string, ...any)  {}
+func (discardLogger) Error(context.Context, string, ...any) {}
+
+func testQuiz(revealAt time.Time) *model.Quiz {
+	return &model.Quiz{
+		Question: "Độ phức tạp?",
+		Options:  []string{"O(1)", "O(n)", "O(log n)", "O(n^2)"},
+		Answer:   1,
+		RevealAt: revealAt,
+	}
+}
+
+func TestRevealPostsQuizWhosePollClosedOnALaterDay(t *testing.T) {
+	loc := time.UTC
+	// The digest ran at 21:00, after that day's 20:00 reveal, so the poll closes tomorrow at 20:00.
+	revealAt := time.Date(2026, 3, 2, 20, 0, 0, 0, loc)
+	store := &memoryRunStore{runs: map[string]model.DailyRun{
+		"2026-03-01": {Date: "2026-03-01", Quiz: testQuiz(revealAt)},
+	}}
+	notifier := &recordingNotifier{}
+	reveal := NewQuizReveal(store, notifier, discardLogger{})
+
+	// The reveal job on the posting day must not spoil the poll that is still open.
+	reveal.now = func() time.Time { return time.Date(2026, 3, 1, 20, 0, 0, 0, loc) }
+	if err := reveal.Reveal(context.Background()); err == nil {
+		t.Fatal("expected no quiz to be due on the posting day")
+	}
+	if len(notifier.sent) != 0 {
+		t.Fatalf("revealed %d quizzes before the poll closed", len(notifier.sent))
+	}
+
+	reveal.now = func() time.Time { return revealAt }
+	if err := reveal.Reveal(context.Background()); err != nil {
+		t.Fatalf("Reveal: %v", err)
+	}
+	if len(notifier.sent) != 1 {
+		t.Fatalf("sent %d notifications, want 1", len(notifier.sent))
+	}
+	if got := notifier.sent[0].Fields[1].Value; !strings.Contains(got, "O(n)") {
+		t.Errorf("answer field = %q", got)
+	}
+	if store.runs["2026-03-01"].Quiz.RevealedAt.IsZero() {
+		t.Error("quiz was not marked as revealed")
+	}
+
+	// A second reveal on the same day finds nothing left to post.
+	if err := reveal.Reveal(context.Background()); err == nil {
+		t.Error("expected the revealed quiz to be skipped")
+	}
+	if len(notifier.sent) != 1 {
+		t.Errorf("sent %d notifications after re-run, want 1", len(notifier.sent))
+	}
+}
+
+func TestRevealFallsBackToTodayForRunsWithoutTarget(t *testing.T) {
+	now := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+	store := &memoryRunStore{runs: map[string]model.DailyRun{
+		"2026-03-01": {Date: "2026-03-01", Quiz: testQuiz(time.Time{})},
+		"2026-03-02": {Date: "2026-03-02", Quiz: testQuiz(time.Time{})},
+	}}
+	notifier := &recordingNotifier{}
+	reveal := NewQuizReveal(store, notifier, discardLogger{})
+	reveal.now = func() time.Time { return now }
+
+	if err := reveal.Reveal(context.Background()); err != nil {
+		t.Fatalf("Reveal: %v", err)
+	}
+	if len(notifier.sent) != 1 {
+		t.Fatalf("sent %d notifications, want only today's", len(notifier.sent))
+	}
+	if !store.runs["2026-03-01"].Quiz.RevealedAt.IsZero() {
+		t.Error("yesterday's quiz without a target was revealed")
+	}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"bot-viethoang/internal/domain/model"
+	"bot-viethoang/internal/domain/ports"
+)
+
+const recapDays = 7
+
+// WeeklyRecap reviews the problems posted over the last week from the run history.
+type WeeklyRecap struct {
+	runs     ports.RunStore
+	writer   ports.ArticleWriter
+	notifier ports.Notifier
+	logger   ports.Logger
+}
+
+// NewWeeklyRecap constructs a WeeklyRecap use case. writer may be nil, in which case only the
+// problem list is posted.
+func NewWeeklyRecap(runs ports.RunStore, writer ports.ArticleWriter, notifier ports.Notifier, logger ports.Logger) *WeeklyRecap {
+	return &WeeklyRecap{
+		runs:     runs,
+		writer:   writer,
+		notifier: notifier,
+		logger:   logger,
+	}
+}
+
+// Run posts the recap for the seven days ending today.
+func (w *WeeklyRecap) Run(ctx context.Context) error {
+	now := time.Now()
+	from := now.AddDate(0, 0, -(recapDays - 1)).Format(model.RunDateLayout)
+	to := now.Format(model.RunDateLayout)
+
+	runs, err := w.runs.ListRuns(ctx, from, to)
+	if err != nil {
+		return fmt.Errorf("list runs: %w", err)
+	}
+	if len(runs) == 0 {
+		w.logger.Info(ctx, "no runs recorded this week, skipping recap", "from", from, "to", to)
+		return nil
+	}
+
+	recap := w.writeRecap(ctx, runs)
+
+	fields := []model.NotificationField{
+		{Name: "📌 Problems This Week", Value: formatWeekProblems(runs)},
+	}
+	sections := []struct {
+		name  string
+		value string
+	}{
+		{"🧩 Patterns", recap.Patterns},
+		{"⚠️ Pitfalls", recap.Pitfalls},
+		{"🔗 Connections", recap.Connections},
+	}
+	for _, section := range sections {
+		if section.value == "" {
+			continue
+		}
+		fields = append(fields, model.NotificationField{
+			Name:  section.name,
+			Value: trimForDiscord(section.value, 900),
+		})
+	}
+
+	notification := model.Notification{
+		Title:       "📅 Weekly Recap",
+		Description: fmt.Sprintf("Tổng kết %d ngày luyện tập từ %s đến %s.", len(runs), from, to),
+		Fields:      fields,
+	}
+	if err := w.notifier.Send(ctx, notification); err != nil {
+		return err
+	}
+
+	w.logger.Info(ctx, "weekly recap posted", "from", from, "to", to, "runs", len(runs))
+	return nil
+}
+
+func (w *WeeklyRecap) writeRecap(ctx context.Context, runs []model.DailyRun) model.WeeklyRecap {
+	if w.writer == nil {
+		return model.WeeklyRecap{}
+	}
+
+	recap, err := w.writer.WriteRecap(ctx, runs)
+	if err != nil {
+		w.logger.Error(ctx, "failed to write weekly recap", "error", err)
+		return model.WeeklyRecap{}
+	}
+	return recap
+}
+
+// formatWeekProblems lists each day's daily challenge followed by the number of practice problems.
+func formatWeekProblems(runs []model.DailyRun) string {
+	lines := make([]string, 0, len(runs))
+	for _, run := range runs {
+		if run.Daily == nil {
+			continue
+		}
+		line := fmt.Sprintf("`%s` [%s](%s) (%s)", run.Date, run.Daily.Title, run.Daily.Link, run.Daily.Difficulty)
+		if len(run.Random) > 0 {
+			line += fmt.Sprintf(" +%d practice", len(run.Random))
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return "Không có bài nào được ghi lại."
+	}
+	return trimForDiscord(strings.Join(lines, "\n"), 1000)
+}